@@ -1,38 +1,92 @@
 package cache
 
-import badger "github.com/dgraph-io/badger/v4"
+import (
+	"sync/atomic"
+	"time"
+
+	badger "github.com/dgraph-io/badger/v4"
+)
 
 type (
+	BadgerCacheOption func(*badgerCache)
+
+	// badgerCache stores the values encoded with codec together with the
+	// fetch time (see encodeValue). Values written by older versions without
+	// codec header are still read.
 	badgerCache struct {
-		db *badger.DB
+		db     *badger.DB
+		codec  Codec
+		hits   atomic.Uint64
+		misses atomic.Uint64
 	}
 )
 
-var _ Cache = (*badgerCache)(nil)
+var (
+	_ Cache         = (*badgerCache)(nil)
+	_ Iterable      = (*badgerCache)(nil)
+	_ StatsProvider = (*badgerCache)(nil)
+	_ EntryReader   = (*badgerCache)(nil)
+	_ EntryWriter   = (*badgerCache)(nil)
+)
+
+// WithCodec sets the codec used to compress new values (default: zstd)
+func WithCodec(arg Codec) BadgerCacheOption {
+	return func(c *badgerCache) {
+		c.codec = arg
+	}
+}
 
-func NewBadgerCache(db *badger.DB) (Cache, error) {
-	return &badgerCache{db: db}, nil
+func NewBadgerCache(db *badger.DB, opts ...BadgerCacheOption) (Cache, error) {
+	c := &badgerCache{db: db, codec: CodecZstd}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 func (c *badgerCache) Get(key string) ([]byte, bool) {
-	var value []byte
+	entry, ok := c.GetEntry(key)
+	if !ok {
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+// GetEntry returns the entry of key. Entries don't expire, FetchedAt is
+// zero for entries written by older versions.
+func (c *badgerCache) GetEntry(key string) (*Entry, bool) {
+	var entry *Entry
 	err := c.db.View(func(txn *badger.Txn) error {
 		item, err := txn.Get([]byte(key))
 		if err != nil {
 			return err
 		}
-		value, err = item.ValueCopy(nil)
-		return err
+		return item.Value(func(val []byte) error {
+			entry, err = decodeEntry(val)
+			return err
+		})
 	})
 	if err != nil {
+		c.misses.Add(1)
 		return nil, false
 	}
-	return value, true
+	c.hits.Add(1)
+	return entry, true
 }
 
 func (c *badgerCache) Set(key string, value []byte) error {
+	return c.SetEntry(key, &Entry{Value: value, FetchedAt: time.Now()})
+}
+
+// SetEntry stores the value of entry together with its fetch time.
+// ExpiresAt is ignored, entries don't expire.
+func (c *badgerCache) SetEntry(key string, entry *Entry) error {
+	stored, err := encodeValue(c.codec, entry.Value, entry.FetchedAt)
+	if err != nil {
+		return err
+	}
 	return c.db.Update(func(txn *badger.Txn) error {
-		return txn.Set([]byte(key), value)
+		return txn.Set([]byte(key), stored)
 	})
 }
 
@@ -41,3 +95,53 @@ func (c *badgerCache) Delete(key string) error {
 		return txn.Delete([]byte(key))
 	})
 }
+
+func (c *badgerCache) Iterate(
+	prefix string,
+	fn func(key string, value []byte) error,
+) error {
+	return c.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+		p := []byte(prefix)
+		for it.Seek(p); it.ValidForPrefix(p); it.Next() {
+			item := it.Item()
+			if err := item.Value(func(val []byte) error {
+				value, err := decodeValue(val)
+				if err != nil {
+					return err
+				}
+				return fn(string(item.Key()), value)
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Stats returns the hits and misses since creation and the sizes of all
+// entries (stored and uncompressed) in total and per endpoint family.
+// This scans all keys. Values are read without prefetching and are not
+// decoded, the uncompressed size is taken from their header.
+func (c *badgerCache) Stats() Stats {
+	ret := Stats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	//nolint:errcheck // stats are best effort
+	c.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(opts)
+		defer it.Close()
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			//nolint:errcheck // stats are best effort
+			item.Value(func(val []byte) error {
+				h, _ := parseHeader(val)
+				ret.addFamily(string(item.Key()), 1, int64(len(val)), int64(h.size))
+				return nil
+			})
+		}
+		return nil
+	})
+	return ret
+}
@@ -1,11 +1,118 @@
 package cache
 
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
 type Cache interface {
 	Get(key string) ([]byte, bool)
 	Set(key string, value []byte) error
 	Delete(key string) error
 }
 
+type (
+	// Stats contains the counters of a cache
+	Stats struct {
+		Hits      uint64
+		Misses    uint64
+		Evictions uint64
+		Entries   int
+		Size      int64 // bytes
+		// only provided by caches storing compressed values
+		UncompressedSize int64
+		// per endpoint family (see EndpointFamily), only provided by
+		// persistent caches
+		Families map[string]FamilyStats
+	}
+	FamilyStats struct {
+		Entries          int
+		Size             int64
+		UncompressedSize int64
+	}
+
+	// StatsProvider is implemented by caches that collect Stats.
+	StatsProvider interface {
+		Stats() Stats
+	}
+
+	// Entry is a cache entry together with its metadata
+	Entry struct {
+		Value     []byte
+		FetchedAt time.Time // zero if unknown
+		ExpiresAt time.Time // zero: no expiry
+	}
+
+	// EntryReader is implemented by caches that keep the metadata
+	// of their entries.
+	EntryReader interface {
+		// GetEntry returns the entry of key even if it is expired.
+		GetEntry(key string) (*Entry, bool)
+	}
+
+	// EntryWriter is implemented by caches that can store an entry
+	// together with its metadata.
+	EntryWriter interface {
+		// SetEntry stores entry under key, keeping its FetchedAt.
+		SetEntry(key string, entry *Entry) error
+	}
+
+	// TTLProvider is implemented by caches whose entries expire
+	// after a fixed time.
+	TTLProvider interface {
+		TTL() time.Duration // 0: no expiry
+	}
+)
+
+// Iterable is implemented by caches that can enumerate their entries.
+type Iterable interface {
+	// Iterate calls fn for each entry whose key starts with prefix.
+	// The value must not be retained after fn returns.
+	// Iteration stops on the first error returned by fn.
+	Iterate(prefix string, fn func(key string, value []byte) error) error
+}
+
+// Expired reports whether the entry is expired at now
+func (e *Entry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt)
+}
+
+// NormalizeKey sorts the query params of key, so requests with the same
+// params in different order share a cache entry.
+func NormalizeKey(key string) string {
+	u, err := url.Parse(key)
+	if err != nil || u.RawQuery == "" {
+		return key
+	}
+	return u.Path + "?" + u.Query().Encode()
+}
+
+// EndpointFamily returns the first path element below /data of key,
+// for example "series" for "/data/series/season_list?season_year=2026".
+func EndpointFamily(key string) string {
+	path, _, _ := strings.Cut(key, "?")
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "/"), "data/")
+	family, _, _ := strings.Cut(path, "/")
+	return family
+}
+
+// addFamily adds entries with the given sizes of the endpoint family of key
+func (s *Stats) addFamily(key string, entries int, size, uncompressed int64) {
+	if s.Families == nil {
+		s.Families = map[string]FamilyStats{}
+	}
+	family := EndpointFamily(key)
+	f := s.Families[family]
+	f.Entries += entries
+	f.Size += size
+	f.UncompressedSize += uncompressed
+	s.Families[family] = f
+	s.Entries += entries
+	s.Size += size
+	s.UncompressedSize += uncompressed
+}
+
 type NoopCache struct{}
 
 var _ Cache = (*NoopCache)(nil)
@@ -0,0 +1,167 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec identifies the compression of a stored value.
+// Encoded values start with the codec byte followed by the uncompressed
+// size (uvarint). If flagFetchedAt is set in the codec byte, the size is
+// followed by the fetch time (varint, unix milliseconds).
+// Values starting with any other byte are stored as is.
+type Codec byte
+
+const (
+	CodecNone Codec = iota + 1
+	CodecGzip
+	CodecZstd
+)
+
+const flagFetchedAt = 0x80
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+func (c Codec) String() string {
+	switch c {
+	case CodecNone:
+		return "none"
+	case CodecGzip:
+		return "gzip"
+	case CodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("codec(%d)", byte(c))
+	}
+}
+
+// ParseCodec returns the codec for none, gzip or zstd
+func ParseCodec(s string) (Codec, error) {
+	for _, c := range []Codec{CodecNone, CodecGzip, CodecZstd} {
+		if strings.EqualFold(s, c.String()) {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown codec: %s", s)
+}
+
+// encodeValue compresses value with c and adds fetchedAt (unless zero) to the header.
+// If compression doesn't reduce the size, value is stored uncompressed.
+func encodeValue(c Codec, value []byte, fetchedAt time.Time) ([]byte, error) {
+	var payload []byte
+	switch c {
+	case CodecGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(value); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		payload = buf.Bytes()
+	case CodecZstd:
+		payload = zstdEncoder.EncodeAll(value, nil)
+	case CodecNone:
+	}
+	if payload == nil || len(payload) >= len(value) {
+		c, payload = CodecNone, value
+	}
+	ret := make([]byte, 1, 1+2*binary.MaxVarintLen64+len(payload))
+	ret[0] = byte(c)
+	ret = binary.AppendUvarint(ret, uint64(len(value)))
+	if !fetchedAt.IsZero() {
+		ret[0] |= flagFetchedAt
+		ret = binary.AppendVarint(ret, fetchedAt.UnixMilli())
+	}
+	return append(ret, payload...), nil
+}
+
+// valueHeader is the header of an encoded value
+type valueHeader struct {
+	codec     Codec
+	size      uint64    // uncompressed
+	fetchedAt time.Time // zero if not recorded
+	headerLen int
+}
+
+// parseHeader returns the header of a stored value. For values stored
+// without header, ok is false and size is the length of the value.
+func parseHeader(stored []byte) (h valueHeader, ok bool) {
+	h.size = uint64(len(stored))
+	if len(stored) == 0 {
+		return h, false
+	}
+	c := Codec(stored[0] &^ flagFetchedAt)
+	if c != CodecNone && c != CodecGzip && c != CodecZstd {
+		return h, false
+	}
+	size, n := binary.Uvarint(stored[1:])
+	if n <= 0 {
+		return h, false
+	}
+	ret := valueHeader{codec: c, size: size, headerLen: 1 + n}
+	if stored[0]&flagFetchedAt != 0 {
+		millis, m := binary.Varint(stored[ret.headerLen:])
+		if m <= 0 {
+			return h, false
+		}
+		ret.fetchedAt = time.UnixMilli(millis)
+		ret.headerLen += m
+	}
+	return ret, true
+}
+
+// decodeValue returns the uncompressed value of a stored value
+func decodeValue(stored []byte) ([]byte, error) {
+	entry, err := decodeEntry(stored)
+	if err != nil {
+		return nil, err
+	}
+	return entry.Value, nil
+}
+
+// decodeEntry returns the uncompressed value of a stored value together with
+// the metadata recorded in the header
+func decodeEntry(stored []byte) (*Entry, error) {
+	h, ok := parseHeader(stored)
+	if !ok {
+		return &Entry{Value: bytes.Clone(stored)}, nil
+	}
+	value, err := decodePayload(&h, stored[h.headerLen:])
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{Value: value, FetchedAt: h.fetchedAt}, nil
+}
+
+func decodePayload(h *valueHeader, payload []byte) ([]byte, error) {
+	size := h.size
+	switch h.codec {
+	case CodecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		ret := bytes.NewBuffer(make([]byte, 0, size))
+		if _, err := io.CopyN(ret, r, int64(size)); err != nil {
+			return nil, err
+		}
+		return ret.Bytes(), nil
+	case CodecZstd:
+		return zstdDecoder.DecodeAll(payload, make([]byte, 0, size))
+	default:
+		return bytes.Clone(payload), nil
+	}
+}
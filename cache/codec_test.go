@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeValue(t *testing.T) {
+	fetchedAt := time.UnixMilli(1767225600123)
+	compressible := []byte(strings.Repeat(`{"car_id":1,"car_name":"Skip Barber"}`, 50))
+	short := []byte(`{}`)
+	tests := []struct {
+		name      string
+		codec     Codec
+		value     []byte
+		wantCodec Codec
+	}{
+		{"zstd", CodecZstd, compressible, CodecZstd},
+		{"gzip", CodecGzip, compressible, CodecGzip},
+		{"none", CodecNone, compressible, CodecNone},
+		{"zstd not smaller", CodecZstd, short, CodecNone},
+		{"gzip not smaller", CodecGzip, short, CodecNone},
+		{"empty", CodecZstd, []byte{}, CodecNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored, err := encodeValue(tt.codec, tt.value, fetchedAt)
+			if err != nil {
+				t.Fatalf("encodeValue() error = %v", err)
+			}
+			h, ok := parseHeader(stored)
+			if !ok {
+				t.Fatalf("parseHeader() ok = false")
+			}
+			if h.codec != tt.wantCodec {
+				t.Errorf("codec = %v, want %v", h.codec, tt.wantCodec)
+			}
+			if h.size != uint64(len(tt.value)) {
+				t.Errorf("size = %d, want %d", h.size, len(tt.value))
+			}
+			if !h.fetchedAt.Equal(fetchedAt) {
+				t.Errorf("fetchedAt = %v, want %v", h.fetchedAt, fetchedAt)
+			}
+			entry, err := decodeEntry(stored)
+			if err != nil {
+				t.Fatalf("decodeEntry() error = %v", err)
+			}
+			if !bytes.Equal(entry.Value, tt.value) {
+				t.Errorf("value = %q, want %q", entry.Value, tt.value)
+			}
+			if !entry.FetchedAt.Equal(fetchedAt) {
+				t.Errorf("entry.FetchedAt = %v, want %v", entry.FetchedAt, fetchedAt)
+			}
+		})
+	}
+}
+
+func TestParseHeader(t *testing.T) {
+	// header without fetch time as written by the first codec version
+	withoutTime := append([]byte{byte(CodecNone)},
+		binary.AppendUvarint(nil, 2)...)
+	withoutTime = append(withoutTime, "{}"...)
+	tests := []struct {
+		name       string
+		stored     []byte
+		wantOK     bool
+		wantCodec  Codec
+		wantSize   uint64
+		wantHeader int
+	}{
+		{"empty", []byte{}, false, 0, 0, 0},
+		{"legacy json object", []byte(`{"a":1}`), false, 0, 7, 0},
+		{"legacy json array", []byte(`[1,2]`), false, 0, 5, 0},
+		{"without fetch time", withoutTime, true, CodecNone, 2, 2},
+		{"truncated size", []byte{byte(CodecZstd), 0x80}, false, 0, 2, 0},
+		{
+			"truncated fetch time",
+			[]byte{byte(CodecNone) | flagFetchedAt, 2, 0x80},
+			false, 0, 3, 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, ok := parseHeader(tt.stored)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if h.codec != tt.wantCodec {
+				t.Errorf("codec = %v, want %v", h.codec, tt.wantCodec)
+			}
+			if h.size != tt.wantSize {
+				t.Errorf("size = %d, want %d", h.size, tt.wantSize)
+			}
+			if h.headerLen != tt.wantHeader {
+				t.Errorf("headerLen = %d, want %d", h.headerLen, tt.wantHeader)
+			}
+			if !h.fetchedAt.IsZero() {
+				t.Errorf("fetchedAt = %v, want zero", h.fetchedAt)
+			}
+		})
+	}
+}
+
+func TestDecodeLegacyValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored []byte
+	}{
+		{"json object", []byte(`{"success":true}`)},
+		{"json array", []byte(`[{"car_id":1}]`)},
+		{"empty", []byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := decodeEntry(tt.stored)
+			if err != nil {
+				t.Fatalf("decodeEntry() error = %v", err)
+			}
+			if !bytes.Equal(entry.Value, tt.stored) {
+				t.Errorf("value = %q, want %q", entry.Value, tt.stored)
+			}
+			if !entry.FetchedAt.IsZero() {
+				t.Errorf("FetchedAt = %v, want zero", entry.FetchedAt)
+			}
+		})
+	}
+}
+
+func TestParseCodec(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Codec
+		wantErr bool
+	}{
+		{"none", CodecNone, false},
+		{"gzip", CodecGzip, false},
+		{"ZSTD", CodecZstd, false},
+		{"lz4", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseCodec(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type (
+	// Meta describes a cache entry
+	Meta struct {
+		Key        string        `json:"key"`
+		Endpoint   string        `json:"endpoint"`
+		FetchedAt  time.Time     `json:"fetchedAt"`
+		TTL        time.Duration `json:"ttl,omitempty"` // 0: no expiry
+		Hash       string        `json:"hash"`          // sha256 of the data
+		Size       int           `json:"size"`
+		Compressed bool          `json:"compressed,omitempty"`
+	}
+
+	FileCacheOption func(*fileCache)
+
+	// fileCache stores one file per key below dir together with
+	// a sidecar file containing the Meta of the entry.
+	// Files are written to a temp file and renamed afterwards, so multiple
+	// processes may use the same directory.
+	fileCache struct {
+		dir      string
+		compress bool
+		ttl      time.Duration
+	}
+)
+
+const (
+	metaSuffix = ".meta.json"
+	// file names longer than this are replaced by their hash
+	maxFileNameLen = 200
+)
+
+var (
+	_ Cache       = (*fileCache)(nil)
+	_ Iterable    = (*fileCache)(nil)
+	_ EntryReader = (*fileCache)(nil)
+	_ TTLProvider = (*fileCache)(nil)
+)
+
+// WithCompression enables gzip compression of the stored data
+func WithCompression(arg bool) FileCacheOption {
+	return func(c *fileCache) {
+		c.compress = arg
+	}
+}
+
+// WithTTL sets the time to live of new entries (0: no expiry)
+func WithTTL(arg time.Duration) FileCacheOption {
+	return func(c *fileCache) {
+		c.ttl = arg
+	}
+}
+
+func NewFileCache(dir string, opts ...FileCacheOption) (Cache, error) {
+	c := &fileCache{dir: dir}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Expired reports whether the entry is expired at now
+func (m *Meta) Expired(now time.Time) bool {
+	return m.TTL > 0 && now.After(m.FetchedAt.Add(m.TTL))
+}
+
+func (c *fileCache) Get(key string) ([]byte, bool) {
+	entry, ok := c.GetEntry(key)
+	if !ok || entry.Expired(time.Now()) {
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+func (c *fileCache) GetEntry(key string) (*Entry, bool) {
+	name := c.fileName(key)
+	meta, err := readMeta(name + metaSuffix)
+	if err != nil || meta.Key != NormalizeKey(key) {
+		return nil, false
+	}
+	data, err := readData(name, meta.Compressed)
+	if err != nil || hashOf(data) != meta.Hash {
+		// missing or replaced by another process in the meantime
+		return nil, false
+	}
+	entry := &Entry{Value: data, FetchedAt: meta.FetchedAt}
+	if meta.TTL > 0 {
+		entry.ExpiresAt = meta.FetchedAt.Add(meta.TTL)
+	}
+	return entry, true
+}
+
+func (c *fileCache) TTL() time.Duration {
+	return c.ttl
+}
+
+func (c *fileCache) Set(key string, value []byte) error {
+	name := c.fileName(key)
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	stored := value
+	if c.compress {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(value); err != nil {
+			return err
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		stored = buf.Bytes()
+	}
+	if err := writeAtomic(name, stored); err != nil {
+		return err
+	}
+	meta, err := json.Marshal(Meta{
+		Key:        NormalizeKey(key),
+		Endpoint:   endpointOf(key),
+		FetchedAt:  time.Now().UTC(),
+		TTL:        c.ttl,
+		Hash:       hashOf(value),
+		Size:       len(value),
+		Compressed: c.compress,
+	})
+	if err != nil {
+		return err
+	}
+	return writeAtomic(name+metaSuffix, meta)
+}
+
+func (c *fileCache) Delete(key string) error {
+	name := c.fileName(key)
+	for _, f := range []string{name + metaSuffix, name} {
+		if err := os.Remove(f); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *fileCache) Iterate(
+	prefix string,
+	fn func(key string, value []byte) error,
+) error {
+	return filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, metaSuffix) {
+			return nil
+		}
+		meta, metaErr := readMeta(path)
+		if metaErr != nil || !strings.HasPrefix(meta.Key, prefix) {
+			return nil //nolint:nilerr // skip unreadable entries
+		}
+		if data, ok := c.Get(meta.Key); ok {
+			return fn(meta.Key, data)
+		}
+		return nil
+	})
+}
+
+// fileName returns the name of the data file of key.
+// The path of the key is used as directory, the (sorted) query as file name.
+func (c *fileCache) fileName(key string) string {
+	path, query, _ := strings.Cut(NormalizeKey(key), "?")
+	name := "_"
+	if query != "" {
+		name = url.PathEscape(query)
+	}
+	if len(name) > maxFileNameLen {
+		name = hashOf([]byte(query))
+	}
+	return filepath.Join(c.dir, filepath.FromSlash(path), name)
+}
+
+func endpointOf(key string) string {
+	path, _, _ := strings.Cut(key, "?")
+	return path
+}
+
+func hashOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func readMeta(name string) (*Meta, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var meta Meta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+func readData(name string, compressed bool) ([]byte, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if !compressed {
+		return io.ReadAll(f)
+	}
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// writeAtomic writes data to a temp file which is renamed to name afterwards
+func writeAtomic(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	// no-op after successful rename
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
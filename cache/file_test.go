@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileCacheGetSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		compress bool
+		key      string
+		lookup   string // key used for Get (params in other order)
+	}{
+		{"plain", false, "/data/car/get", "/data/car/get"},
+		{"compressed", true, "/data/car/get", "/data/car/get"},
+		{
+			"normalized params", false,
+			"/data/series/season_list?season_year=2026&season_quarter=1",
+			"/data/series/season_list?season_quarter=1&season_year=2026",
+		},
+		{
+			"long params", true,
+			"/data/member/get?cust_ids=" + strings.Repeat("123456%2C", 40),
+			"/data/member/get?cust_ids=" + strings.Repeat("123456%2C", 40),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewFileCache(t.TempDir(), WithCompression(tt.compress))
+			if err != nil {
+				t.Fatalf("NewFileCache() error = %v", err)
+			}
+			value := []byte(`{"success":true}`)
+			if err := c.Set(tt.key, value); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			got, ok := c.Get(tt.lookup)
+			if !ok || !bytes.Equal(got, value) {
+				t.Errorf("Get() = %q, %v, want %q, true", got, ok, value)
+			}
+			if err := c.Delete(tt.lookup); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, ok := c.Get(tt.key); ok {
+				t.Errorf("Get() after Delete() ok = true")
+			}
+		})
+	}
+}
+
+func TestFileCacheExpiry(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		wantExpired bool
+	}{
+		{"no ttl", 0, false},
+		{"valid", time.Hour, false},
+		{"expired", time.Nanosecond, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			c, err := NewFileCache(dir, WithTTL(tt.ttl))
+			if err != nil {
+				t.Fatalf("NewFileCache() error = %v", err)
+			}
+			// an entry of another ttl in the same directory
+			other, _ := NewFileCache(dir)
+			if err := other.Set("/data/track/get", []byte(`[]`)); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			if err := c.Set("/data/car/get", []byte(`[]`)); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			time.Sleep(2 * time.Millisecond)
+
+			_, ok := c.Get("/data/car/get")
+			if ok == tt.wantExpired {
+				t.Errorf("Get() ok = %v, want %v", ok, !tt.wantExpired)
+			}
+			er, _ := c.(EntryReader)
+			entry, ok := er.GetEntry("/data/car/get")
+			if !ok {
+				t.Fatalf("GetEntry() ok = false, want expired entries to be returned")
+			}
+			if entry.Expired(time.Now()) != tt.wantExpired {
+				t.Errorf("Expired() = %v, want %v", !tt.wantExpired, tt.wantExpired)
+			}
+			want := []string{"/data/track/get"}
+			if !tt.wantExpired {
+				want = []string{"/data/car/get", "/data/track/get"}
+			}
+			if got := iterateKeys(t, c, "/data/"); !slices.Equal(got, want) {
+				t.Errorf("Iterate() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFileCacheReplacedData(t *testing.T) {
+	c, err := NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCache() error = %v", err)
+	}
+	if err := c.Set("/data/car/get", []byte(`[1]`)); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	// data replaced without matching meta (for example by another process)
+	fc, _ := c.(*fileCache)
+	name := fc.fileName("/data/car/get")
+	if err := os.WriteFile(name, []byte(`[2]`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := c.Get("/data/car/get"); ok {
+		t.Errorf("Get() = %q, want miss for data not matching the hash", got)
+	}
+}
+
+// iterateKeys returns the keys of the entries of c starting with prefix
+func iterateKeys(t *testing.T, c Cache, prefix string) []string {
+	t.Helper()
+	var ret []string
+	it, _ := c.(Iterable)
+	err := it.Iterate(prefix, func(key string, _ []byte) error {
+		ret = append(ret, key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Iterate() error = %v", err)
+	}
+	slices.Sort(ret)
+	return ret
+}
@@ -0,0 +1,197 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type (
+	// memoryCache is a size bounded LRU cache. If next is set, it is used
+	// as second tier: misses are looked up in next and writes go to both.
+	// If next keeps metadata (EntryReader), written entries are added to the
+	// memory tier on the first read only, so expiry times of next are kept.
+	memoryCache struct {
+		mu      sync.Mutex
+		maxSize int64
+		next    Cache
+		lru     *list.List // front: most recently used
+		entries map[string]*list.Element
+		stats   Stats
+	}
+	memoryEntry struct {
+		key string
+		Entry
+	}
+)
+
+var (
+	_ Cache         = (*memoryCache)(nil)
+	_ Iterable      = (*memoryCache)(nil)
+	_ StatsProvider = (*memoryCache)(nil)
+	_ EntryReader   = (*memoryCache)(nil)
+	_ TTLProvider   = (*memoryCache)(nil)
+	_ EntryWriter   = (*memoryCache)(nil)
+)
+
+// NewMemoryCache creates an in-memory LRU cache holding up to maxSize bytes
+// of values. If next is not nil, the memory cache acts as first tier in front
+// of next. Values returned by Get are shared and must not be modified.
+func NewMemoryCache(maxSize int64, next Cache) Cache {
+	return &memoryCache{
+		maxSize: maxSize,
+		next:    next,
+		lru:     list.New(),
+		entries: map[string]*list.Element{},
+	}
+}
+
+func (c *memoryCache) Get(key string) ([]byte, bool) {
+	entry, ok := c.GetEntry(key)
+	if !ok || entry.Expired(time.Now()) {
+		return nil, false
+	}
+	return entry.Value, true
+}
+
+func (c *memoryCache) Set(key string, value []byte) error {
+	if _, ok := c.next.(EntryReader); !ok {
+		c.put(key, &Entry{Value: value, FetchedAt: time.Now()})
+	} else {
+		c.drop(key)
+	}
+	if c.next != nil {
+		return c.next.Set(key, value)
+	}
+	return nil
+}
+
+// SetEntry is like Set but keeps the fetch time of entry if next implements
+// EntryWriter as well.
+func (c *memoryCache) SetEntry(key string, entry *Entry) error {
+	ew, ok := c.next.(EntryWriter)
+	if !ok {
+		return c.Set(key, entry.Value)
+	}
+	c.drop(key)
+	return ew.SetEntry(key, entry)
+}
+
+// TTL returns the TTL of next (entries of the memory tier don't expire
+// on their own)
+func (c *memoryCache) TTL() time.Duration {
+	if tp, ok := c.next.(TTLProvider); ok {
+		return tp.TTL()
+	}
+	return 0
+}
+
+func (c *memoryCache) Delete(key string) error {
+	c.drop(key)
+	if c.next != nil {
+		return c.next.Delete(key)
+	}
+	return nil
+}
+
+// Iterate delegates to next (the memory tier only holds a subset).
+func (c *memoryCache) Iterate(
+	prefix string,
+	fn func(key string, value []byte) error,
+) error {
+	if it, ok := c.next.(Iterable); ok {
+		return it.Iterate(prefix, fn)
+	}
+	return nil
+}
+
+// Stats returns the counters of the memory tier
+func (c *memoryCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ret := c.stats
+	ret.Entries = c.lru.Len()
+	return ret
+}
+
+// GetEntry returns the entry from the memory tier or, if not present there,
+// from next. Expired entries of next are not added to the memory tier.
+func (c *memoryCache) GetEntry(key string) (*Entry, bool) {
+	if entry, ok := c.getMemory(key); ok {
+		return entry, true
+	}
+	var entry *Entry
+	switch next := c.next.(type) {
+	case nil:
+		return nil, false
+	case EntryReader:
+		var ok bool
+		if entry, ok = next.GetEntry(key); !ok {
+			return nil, false
+		}
+		if entry.Expired(time.Now()) {
+			return entry, true
+		}
+	default:
+		value, ok := next.Get(key)
+		if !ok {
+			return nil, false
+		}
+		entry = &Entry{Value: value}
+	}
+	c.put(key, entry)
+	return entry, true
+}
+
+// getMemory returns the entry from the memory tier. Expired entries are removed.
+func (c *memoryCache) getMemory(key string) (*Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if ok {
+		entry, _ := e.Value.(*memoryEntry)
+		if !entry.Expired(time.Now()) {
+			c.lru.MoveToFront(e)
+			c.stats.Hits++
+			ret := entry.Entry
+			return &ret, true
+		}
+		c.remove(e)
+	}
+	c.stats.Misses++
+	return nil, false
+}
+
+func (c *memoryCache) put(key string, entry *Entry) {
+	size := int64(len(entry.Value))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+	if size > c.maxSize {
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&memoryEntry{key: key, Entry: *entry})
+	c.stats.Size += size
+	for c.stats.Size > c.maxSize {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// drop removes key from the memory tier only
+func (c *memoryCache) drop(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.remove(e)
+	}
+}
+
+// remove removes e, the caller must hold the lock
+func (c *memoryCache) remove(e *list.Element) {
+	entry, _ := c.lru.Remove(e).(*memoryEntry)
+	delete(c.entries, entry.key)
+	c.stats.Size -= int64(len(entry.Value))
+}
@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheEviction(t *testing.T) {
+	type op struct {
+		key  string
+		size int // 0: Get instead of Set
+	}
+	tests := []struct {
+		name          string
+		maxSize       int64
+		ops           []op
+		wantKeys      []string
+		wantEvictions uint64
+		wantSize      int64
+	}{
+		{
+			"fits", 12,
+			[]op{{"a", 4}, {"b", 4}, {"c", 4}},
+			[]string{"a", "b", "c"},
+			0, 12,
+		},
+		{
+			"least recently used is evicted", 10,
+			[]op{{"a", 4}, {"b", 4}, {"c", 4}},
+			[]string{"b", "c"},
+			1, 8,
+		},
+		{
+			"get marks as recently used", 10,
+			[]op{{"a", 4}, {"b", 4}, {"a", 0}, {"c", 4}},
+			[]string{"a", "c"},
+			1, 8,
+		},
+		{
+			"several entries evicted", 10,
+			[]op{{"a", 3}, {"b", 3}, {"c", 3}, {"d", 9}},
+			[]string{"d"},
+			3, 9,
+		},
+		{
+			"too large value is not kept", 10,
+			[]op{{"a", 4}, {"x", 11}},
+			[]string{"a"},
+			0, 4,
+		},
+		{
+			"replaced value", 10,
+			[]op{{"a", 4}, {"a", 6}, {"b", 4}},
+			[]string{"a", "b"},
+			0, 10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewMemoryCache(tt.maxSize, nil)
+			for _, o := range tt.ops {
+				if o.size == 0 {
+					c.Get(o.key)
+					continue
+				}
+				if err := c.Set(o.key, []byte(strings.Repeat("x", o.size))); err != nil {
+					t.Fatalf("Set() error = %v", err)
+				}
+			}
+			sp, _ := c.(StatsProvider)
+			stats := sp.Stats()
+			if stats.Evictions != tt.wantEvictions {
+				t.Errorf("evictions = %d, want %d", stats.Evictions, tt.wantEvictions)
+			}
+			if stats.Size != tt.wantSize {
+				t.Errorf("size = %d, want %d", stats.Size, tt.wantSize)
+			}
+			if stats.Entries != len(tt.wantKeys) {
+				t.Errorf("entries = %d, want %d", stats.Entries, len(tt.wantKeys))
+			}
+			for _, key := range []string{"a", "b", "c", "d", "x"} {
+				_, ok := c.Get(key)
+				if want := slices.Contains(tt.wantKeys, key); ok != want {
+					t.Errorf("Get(%s) ok = %v, want %v", key, ok, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		wait        time.Duration
+		wantFirst   bool // usable right after Set
+		wantExpired bool // expired after wait
+	}{
+		{"no ttl", 0, 0, true, false},
+		{"valid", time.Hour, 0, true, false},
+		{"expired in next", time.Nanosecond, time.Millisecond, false, true},
+		{"expired in memory", 50 * time.Millisecond, 60 * time.Millisecond, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := NewFileCache(t.TempDir(), WithTTL(tt.ttl))
+			if err != nil {
+				t.Fatalf("NewFileCache() error = %v", err)
+			}
+			c := NewMemoryCache(1024, next)
+			if err = c.Set("/data/car/get", []byte(`[]`)); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			sp, _ := c.(StatsProvider)
+			if _, ok := c.Get("/data/car/get"); ok != tt.wantFirst {
+				t.Fatalf("Get() ok = %v, want %v", ok, tt.wantFirst)
+			}
+			// entries of next are added to the memory tier on the first read
+			if got, want := sp.Stats().Entries, boolInt(tt.wantFirst); got != want {
+				t.Errorf("entries after first read = %d, want %d", got, want)
+			}
+			time.Sleep(tt.wait)
+			_, ok := c.Get("/data/car/get")
+			if ok == tt.wantExpired {
+				t.Errorf("Get() ok = %v, want %v", ok, !tt.wantExpired)
+			}
+			if got, want := sp.Stats().Entries, boolInt(!tt.wantExpired); got != want {
+				t.Errorf("entries = %d, want %d", got, want)
+			}
+			er, _ := c.(EntryReader)
+			if entry, found := er.GetEntry("/data/car/get"); !found ||
+				entry.Expired(time.Now()) != tt.wantExpired {
+				t.Errorf("GetEntry() = %v, %v, want entry with expired %v",
+					entry, found, tt.wantExpired)
+			}
+		})
+	}
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
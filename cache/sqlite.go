@@ -0,0 +1,190 @@
+package cache
+
+import (
+	"database/sql"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	// pure Go sqlite driver, no cgo needed
+	_ "modernc.org/sqlite"
+)
+
+type (
+	SQLiteCacheOption func(*sqliteCache)
+
+	// sqliteCache stores the entries in the table cache of a SQLite database.
+	// Bodies are stored as text, so JSON responses can be queried with the
+	// SQLite JSON functions. Times are stored as RFC3339 UTC text.
+	sqliteCache struct {
+		db     *sql.DB
+		ttl    time.Duration
+		hits   atomic.Uint64
+		misses atomic.Uint64
+	}
+)
+
+const (
+	sqliteTimeLayout = "2006-01-02T15:04:05Z"
+	sqliteSchema     = `
+CREATE TABLE IF NOT EXISTS cache (
+	key        TEXT PRIMARY KEY,
+	endpoint   TEXT NOT NULL,
+	params     TEXT NOT NULL,
+	fetched_at TEXT NOT NULL,
+	expires_at TEXT,
+	body       TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS cache_endpoint ON cache(endpoint);
+`
+)
+
+var (
+	_ Cache         = (*sqliteCache)(nil)
+	_ Iterable      = (*sqliteCache)(nil)
+	_ StatsProvider = (*sqliteCache)(nil)
+	_ EntryReader   = (*sqliteCache)(nil)
+	_ TTLProvider   = (*sqliteCache)(nil)
+)
+
+// OpenSQLite opens the SQLite database file with settings suitable for
+// concurrent readers (WAL mode, busy timeout).
+func OpenSQLite(filename string) (*sql.DB, error) {
+	return sql.Open("sqlite", "file:"+filename+
+		"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+}
+
+// WithSQLiteTTL sets the time to live of new entries (0: no expiry)
+func WithSQLiteTTL(arg time.Duration) SQLiteCacheOption {
+	return func(c *sqliteCache) {
+		c.ttl = arg
+	}
+}
+
+// NewSQLiteCache creates the cache table in db if needed.
+func NewSQLiteCache(db *sql.DB, opts ...SQLiteCacheOption) (Cache, error) {
+	c := &sqliteCache{db: db}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *sqliteCache) Get(key string) ([]byte, bool) {
+	var body string
+	err := c.db.QueryRow(
+		`SELECT body FROM cache
+		 WHERE key = ? AND (expires_at IS NULL OR expires_at > ?)`,
+		NormalizeKey(key), time.Now().UTC().Format(sqliteTimeLayout),
+	).Scan(&body)
+	if err != nil {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	return []byte(body), true
+}
+
+func (c *sqliteCache) GetEntry(key string) (*Entry, bool) {
+	var body, fetchedAt string
+	var expiresAt sql.NullString
+	err := c.db.QueryRow(
+		`SELECT body, fetched_at, expires_at FROM cache WHERE key = ?`,
+		NormalizeKey(key),
+	).Scan(&body, &fetchedAt, &expiresAt)
+	if err != nil {
+		c.misses.Add(1)
+		return nil, false
+	}
+	c.hits.Add(1)
+	// times which can't be parsed are treated as unknown (zero)
+	entry := &Entry{Value: []byte(body)}
+	entry.FetchedAt, _ = time.Parse(sqliteTimeLayout, fetchedAt)
+	if expiresAt.Valid {
+		entry.ExpiresAt, _ = time.Parse(sqliteTimeLayout, expiresAt.String)
+	}
+	return entry, true
+}
+
+func (c *sqliteCache) TTL() time.Duration {
+	return c.ttl
+}
+
+func (c *sqliteCache) Set(key string, value []byte) error {
+	key = NormalizeKey(key)
+	endpoint, params, _ := strings.Cut(key, "?")
+	now := time.Now().UTC()
+	var expiresAt sql.NullString
+	if c.ttl > 0 {
+		expiresAt = sql.NullString{
+			String: now.Add(c.ttl).Format(sqliteTimeLayout),
+			Valid:  true,
+		}
+	}
+	_, err := c.db.Exec(
+		`INSERT INTO cache (key, endpoint, params, fetched_at, expires_at, body)
+		 VALUES (?, ?, ?, ?, ?, ?)
+		 ON CONFLICT(key) DO UPDATE SET
+		   fetched_at = excluded.fetched_at,
+		   expires_at = excluded.expires_at,
+		   body = excluded.body`,
+		key, endpoint, params, now.Format(sqliteTimeLayout), expiresAt, string(value))
+	return err
+}
+
+func (c *sqliteCache) Delete(key string) error {
+	_, err := c.db.Exec(`DELETE FROM cache WHERE key = ?`, NormalizeKey(key))
+	return err
+}
+
+// Iterate calls fn for all entries which are not expired ordered by key.
+func (c *sqliteCache) Iterate(
+	prefix string,
+	fn func(key string, value []byte) error,
+) error {
+	rows, err := c.db.Query(
+		`SELECT key, body FROM cache
+		 WHERE substr(key, 1, ?) = ? AND (expires_at IS NULL OR expires_at > ?)
+		 ORDER BY key`,
+		len(prefix), prefix, time.Now().UTC().Format(sqliteTimeLayout))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key, body string
+		if err := rows.Scan(&key, &body); err != nil {
+			return err
+		}
+		if err := fn(key, []byte(body)); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Stats returns the hits and misses since creation and the current
+// number and size of the entries in total and per endpoint family.
+func (c *sqliteCache) Stats() Stats {
+	ret := Stats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+	rows, err := c.db.Query(
+		`SELECT endpoint, count(*), sum(length(CAST(body AS BLOB)))
+		 FROM cache GROUP BY endpoint`)
+	if err != nil {
+		return ret
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var endpoint string
+		var entries int
+		var size int64
+		if err := rows.Scan(&endpoint, &entries, &size); err != nil {
+			return ret
+		}
+		ret.addFamily(endpoint, entries, size, size)
+	}
+	return ret
+}
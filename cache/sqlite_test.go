@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"bytes"
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func newTestSQLiteCache(t *testing.T, opts ...SQLiteCacheOption) (Cache, *sql.DB) {
+	t.Helper()
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "cache.sqlite"))
+	if err != nil {
+		t.Fatalf("OpenSQLite() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	c, err := NewSQLiteCache(db, opts...)
+	if err != nil {
+		t.Fatalf("NewSQLiteCache() error = %v", err)
+	}
+	return c, db
+}
+
+func TestSQLiteCacheExpiry(t *testing.T) {
+	tests := []struct {
+		name        string
+		ttl         time.Duration
+		expiresAt   string // overrides the stored expiry if set
+		wantExpired bool
+	}{
+		{"no ttl", 0, "", false},
+		{"valid", time.Hour, "", false},
+		{"expired", time.Hour, "2026-01-01T00:00:00Z", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, db := newTestSQLiteCache(t, WithSQLiteTTL(tt.ttl))
+			value := []byte(`{"success":true}`)
+			for _, key := range []string{"/data/car/get", "/data/track/get"} {
+				if err := c.Set(key, value); err != nil {
+					t.Fatalf("Set() error = %v", err)
+				}
+			}
+			if tt.expiresAt != "" {
+				if _, err := db.Exec(`UPDATE cache SET expires_at = ? WHERE key = ?`,
+					tt.expiresAt, "/data/car/get"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, ok := c.Get("/data/car/get")
+			if ok == tt.wantExpired {
+				t.Errorf("Get() ok = %v, want %v", ok, !tt.wantExpired)
+			}
+			if ok && !bytes.Equal(got, value) {
+				t.Errorf("Get() = %q, want %q", got, value)
+			}
+			er, _ := c.(EntryReader)
+			entry, ok := er.GetEntry("/data/car/get")
+			if !ok {
+				t.Fatalf("GetEntry() ok = false, want expired entries to be returned")
+			}
+			if entry.Expired(time.Now()) != tt.wantExpired {
+				t.Errorf("Expired() = %v, want %v", !tt.wantExpired, tt.wantExpired)
+			}
+			if entry.ExpiresAt.IsZero() != (tt.ttl == 0) {
+				t.Errorf("ExpiresAt = %v for ttl %v", entry.ExpiresAt, tt.ttl)
+			}
+			want := []string{"/data/track/get"}
+			if !tt.wantExpired {
+				want = []string{"/data/car/get", "/data/track/get"}
+			}
+			if keys := iterateKeys(t, c, "/data/"); !slices.Equal(keys, want) {
+				t.Errorf("Iterate() = %v, want %v", keys, want)
+			}
+		})
+	}
+}
+
+func TestSQLiteCacheStats(t *testing.T) {
+	c, _ := newTestSQLiteCache(t)
+	for _, key := range []string{
+		"/data/car/get",
+		"/data/car/assets",
+		"/data/series/season_list?season_year=2026&season_quarter=1",
+	} {
+		if err := c.Set(key, []byte(`[]`)); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+	er, _ := c.(EntryReader)
+	lookups := []struct {
+		key string
+		hit bool
+	}{
+		{"/data/car/get", true},
+		{"/data/series/season_list?season_quarter=1&season_year=2026", true},
+		{"/data/track/get", false},
+	}
+	for _, l := range lookups {
+		if _, ok := c.Get(l.key); ok != l.hit {
+			t.Errorf("Get(%s) ok = %v, want %v", l.key, ok, l.hit)
+		}
+		if _, ok := er.GetEntry(l.key); ok != l.hit {
+			t.Errorf("GetEntry(%s) ok = %v, want %v", l.key, ok, l.hit)
+		}
+	}
+	sp, _ := c.(StatsProvider)
+	stats := sp.Stats()
+	if stats.Hits != 4 || stats.Misses != 2 {
+		t.Errorf("hits/misses = %d/%d, want 4/2", stats.Hits, stats.Misses)
+	}
+	if stats.Entries != 3 || stats.Size != 6 {
+		t.Errorf("entries/size = %d/%d, want 3/6", stats.Entries, stats.Size)
+	}
+	wantFamilies := map[string]int{"car": 2, "series": 1}
+	for family, entries := range wantFamilies {
+		if got := stats.Families[family].Entries; got != entries {
+			t.Errorf("entries of %s = %d, want %d", family, got, entries)
+		}
+	}
+}
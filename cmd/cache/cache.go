@@ -0,0 +1,16 @@
+package cache
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCacheCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "cache",
+		Short: "commands related to the cache",
+		Long:  ``,
+	}
+
+	cmd.AddCommand(NewCacheStatsCommand())
+	return &cmd
+}
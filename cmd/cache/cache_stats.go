@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mpapenbr/irdata/cache"
+	"github.com/mpapenbr/irdata/cmd/util"
+	"github.com/mpapenbr/irdata/log"
+)
+
+func NewCacheStatsCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "stats",
+		Short: "show the number and size of the cache entries per endpoint family",
+		Long:  ``,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			showStats()
+			return nil
+		},
+	}
+	return &cmd
+}
+
+func showStats() {
+	app, err := util.InitCache()
+	if err != nil {
+		log.Error("failed to initialize cache", log.ErrorField(err))
+		return
+	}
+	defer app.Close()
+
+	sp, ok := app.Cache.(cache.StatsProvider)
+	if !ok {
+		log.Error("cache does not provide stats")
+		return
+	}
+	stats := sp.Stats()
+	families := make([]string, 0, len(stats.Families))
+	for k := range stats.Families {
+		families = append(families, k)
+	}
+	slices.Sort(families)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "family\tentries\tsize\tuncompressed\t")
+	for _, name := range families {
+		f := stats.Families[name]
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t\n",
+			name, f.Entries, f.Size, f.UncompressedSize)
+	}
+	fmt.Fprintf(w, "total\t%d\t%d\t%d\t\n",
+		stats.Entries, stats.Size, stats.UncompressedSize)
+	//nolint:errcheck // by design
+	w.Flush()
+}
@@ -0,0 +1,16 @@
+package catalog
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCatalogCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "catalog",
+		Short: "commands related to the car, car class and track catalog",
+		Long:  ``,
+	}
+
+	cmd.AddCommand(NewCatalogExportCommand())
+	return &cmd
+}
@@ -0,0 +1,87 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mpapenbr/irdata/cmd/util"
+	"github.com/mpapenbr/irdata/export"
+	"github.com/mpapenbr/irdata/log"
+)
+
+var (
+	outputDir string
+	format    string
+)
+
+func NewCatalogExportCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "export",
+		Short: "export cars, car classes and tracks",
+		Long:  ``,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := export.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			exportCatalog(cmd.Context(), f)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&outputDir, "output-dir", "tmp",
+		"directory to store the catalog files")
+	cmd.Flags().StringVar(&format, "format", "json",
+		"output format (json, ndjson, csv, parquet)")
+
+	return &cmd
+}
+
+func exportCatalog(ctx context.Context, f export.Format) {
+	app, err := util.InitApp()
+	if err != nil {
+		log.Error("failed to initialize app", log.ErrorField(err))
+		return
+	}
+	defer app.Close()
+
+	c, err := app.API.LoadCatalog(ctx)
+	if err != nil {
+		log.Error("failed to load catalog", log.ErrorField(err))
+		return
+	}
+	if f == export.FormatJSON {
+		writeJSON("cars.json", export.SortedValues(c.Cars))
+		writeJSON("carclasses.json", export.SortedValues(c.CarClasses))
+		writeJSON("tracks.json", export.SortedValues(c.Tracks))
+		writeJSON("carassets.json", export.SortedValues(c.CarAssets))
+		writeJSON("trackassets.json", export.SortedValues(c.TrackAssets))
+	} else {
+		writeTable("cars", f, export.Cars(c))
+		writeTable("carclasses", f, export.CarClasses(c))
+		writeTable("tracks", f, export.Tracks(c))
+		writeTable("carassets", f, export.CarAssets(c))
+		writeTable("trackassets", f, export.TrackAssets(c))
+	}
+	log.Info("catalog exported",
+		log.Int("cars", len(c.Cars)),
+		log.Int("car_classes", len(c.CarClasses)),
+		log.Int("tracks", len(c.Tracks)))
+}
+
+func writeTable(name string, f export.Format, t *export.Table) {
+	util.WriteTable(filepath.Join(outputDir, name), f, t)
+}
+
+func writeJSON(filename string, data any) {
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		log.Error("failed to marshal json data",
+			log.String("filename", filename),
+			log.ErrorField(err))
+		return
+	}
+	util.WriteToFile(filepath.Join(outputDir, filename), b)
+}
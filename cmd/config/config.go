@@ -1,6 +1,10 @@
 package config
 
-import "github.com/mpapenbr/irdata/auth"
+import (
+	"time"
+
+	"github.com/mpapenbr/irdata/auth"
+)
 
 var (
 	EnableTelemetry   bool
@@ -9,5 +13,18 @@ var (
 	LogLevel          string
 	OtelOutput        string // output for otel-logger (stdout, grpc)
 	CacheDir          string
+	CacheBackend      string        // fs, badger, sqlite, none
+	CacheCompress     bool          // fs only
+	CacheCodec        string        // badger only: none, gzip, zstd
+	CacheTTL          time.Duration // fs and sqlite only
+	MemoryCacheSize   int64         // bytes, 0 disables the memory cache
+	DecodeMode        string        // lenient, report, strict
+	CacheLinks        bool
+	MaxPayloadSize    int64
+	Offline           bool
+	ServeStale        bool
+	NoCache           bool
+	Refresh           bool
+	MaxAge            time.Duration
 	IrAuthConfig      auth.AuthConfig
 )
@@ -0,0 +1,16 @@
+package hosted
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewHostedCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "hosted",
+		Short: "commands related to hosted sessions",
+		Long:  ``,
+	}
+
+	cmd.AddCommand(NewHostedListCommand())
+	return &cmd
+}
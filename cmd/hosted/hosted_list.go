@@ -0,0 +1,123 @@
+package hosted
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mpapenbr/irdata/cmd/util"
+	"github.com/mpapenbr/irdata/irdata"
+	"github.com/mpapenbr/irdata/log"
+)
+
+var (
+	combined   bool
+	packageID  int
+	name       string
+	hostIDs    []int
+	trackIDs   []int
+	carIDs     []int
+	eventTypes []int
+	noPassword bool
+	minDrivers int
+)
+
+func NewHostedListCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "list",
+		Short: "list hosted sessions",
+		Long:  ``,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			listSessions(cmd.Context(), buildFilter())
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&combined, "combined", false,
+		"include sessions that can only be joined as spectator")
+	cmd.Flags().IntVar(&packageID, "package-id", 0,
+		"restrict to this track package (requires --combined)")
+	cmd.Flags().StringVar(&name, "name", "",
+		"restrict to sessions containing this name (case insensitive)")
+	cmd.Flags().IntSliceVar(&hostIDs, "host-id", []int{},
+		"restrict to sessions hosted by these customers")
+	cmd.Flags().IntSliceVar(&trackIDs, "track-id", []int{},
+		"restrict to sessions on these tracks")
+	cmd.Flags().IntSliceVar(&carIDs, "car-id", []int{},
+		"restrict to sessions allowing one of these cars")
+	cmd.Flags().IntSliceVar(&eventTypes, "event-type", []int{},
+		"restrict to sessions containing these event types (2=practice, 5=race)")
+	cmd.Flags().BoolVar(&noPassword, "no-password", false,
+		"exclude password protected sessions")
+	cmd.Flags().IntVar(&minDrivers, "min-drivers", 0,
+		"minimum number of registered drivers")
+
+	return &cmd
+}
+
+func buildFilter() *irdata.HostedSessionFilter {
+	f := &irdata.HostedSessionFilter{
+		Name:       name,
+		HostIDs:    hostIDs,
+		TrackIDs:   trackIDs,
+		CarIDs:     carIDs,
+		NoPassword: noPassword,
+		MinDrivers: minDrivers,
+	}
+	for _, et := range eventTypes {
+		f.EventTypes = append(f.EventTypes, irdata.EventType(et))
+	}
+	return f
+}
+
+func listSessions(ctx context.Context, filter *irdata.HostedSessionFilter) {
+	app, err := util.InitApp()
+	if err != nil {
+		log.Error("failed to initialize app", log.ErrorField(err))
+		return
+	}
+	defer app.Close()
+
+	var resp *irdata.HostedSessionsResponse
+	if combined {
+		resp, err = app.API.HostedCombinedSessions(ctx, packageID)
+	} else {
+		resp, err = app.API.HostedSessions(ctx)
+	}
+	if err != nil {
+		log.Error("failed to get hosted sessions", log.ErrorField(err))
+		return
+	}
+	printTable(os.Stdout, resp.Filter(filter))
+}
+
+func printTable(out io.Writer, sessions []irdata.HostedSession) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SESSION\tNAME\tHOST\tTRACK\tCARS\tDRIVERS\tPW\tLAUNCH")
+	for i := range sessions {
+		s := &sessions[i]
+		cars := make([]string, len(s.Cars))
+		for j := range s.Cars {
+			cars[j] = s.Cars[j].CarName
+		}
+		pw := ""
+		if s.PasswordProtected {
+			pw = "yes"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%d/%d\t%s\t%s\n",
+			s.SessionID,
+			s.SessionName,
+			s.Host.DisplayName,
+			s.Track.FullName(),
+			strings.Join(cars, ", "),
+			s.NumDrivers, s.MaxDrivers,
+			pw,
+			s.LaunchAt.Local().Format("2006-01-02 15:04"))
+	}
+	//nolint:errcheck // by design
+	w.Flush()
+}
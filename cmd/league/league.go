@@ -0,0 +1,16 @@
+package league
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewLeagueCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "league",
+		Short: "commands related to iRacing leagues",
+		Long:  ``,
+	}
+
+	cmd.AddCommand(NewLeagueSyncCommand())
+	return &cmd
+}
@@ -0,0 +1,183 @@
+package league
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mpapenbr/irdata/cmd/util"
+	"github.com/mpapenbr/irdata/export"
+	"github.com/mpapenbr/irdata/irdata"
+	"github.com/mpapenbr/irdata/log"
+)
+
+type leagueSync struct {
+	ctx        context.Context
+	api        *irdata.IrData
+	leagueID   int
+	dir        string
+	format     export.Format
+	newResults int
+}
+
+var (
+	outputDir      string
+	includeRetired bool
+	format         string
+)
+
+func NewLeagueSyncCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "sync <league_id>",
+		Short: "mirror seasons, sessions and results of a league",
+		Long: `Mirrors the seasons, sessions and subsession results of a league
+into the output directory. Subsession results already present in the
+output directory are not fetched again. Seasons, sessions and standings
+are always requested from the API, bypassing cached data.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			leagueID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid league id %q: %w", args[0], err)
+			}
+			f, err := export.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			syncLeague(cmd.Context(), leagueID, f)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&outputDir, "output-dir", "tmp",
+		"directory to store the league data")
+	cmd.Flags().BoolVar(&includeRetired, "include-retired", false,
+		"also sync retired seasons")
+	cmd.Flags().StringVar(&format, "format", "json",
+		"output format of standings and results (json, ndjson, csv, parquet)")
+
+	return &cmd
+}
+
+func syncLeague(ctx context.Context, leagueID int, f export.Format) {
+	app, err := util.InitApp(irdata.WithRawPayload(true))
+	if err != nil {
+		log.Error("failed to initialize app", log.ErrorField(err))
+		return
+	}
+	defer app.Close()
+
+	s := &leagueSync{
+		ctx:      ctx,
+		api:      app.API,
+		leagueID: leagueID,
+		format:   f,
+		dir:      filepath.Join(outputDir, fmt.Sprintf("league-%d", leagueID)),
+	}
+	// listings and standings change, only subsession results are immutable
+	// and may be served from the cache
+	league, err := s.api.League(ctx, leagueID, false, irdata.ForceRefresh())
+	if err != nil {
+		log.Error("failed to get league data", log.ErrorField(err))
+		util.WriteDriftPayload(filepath.Join(s.dir, "league"), err)
+		return
+	}
+	util.WriteToFile(filepath.Join(s.dir, "league.json"), league.Raw())
+
+	retired := []bool{false}
+	if includeRetired {
+		retired = append(retired, true)
+	}
+	for _, r := range retired {
+		s.syncSeasons(r)
+	}
+	log.Info("league synced",
+		log.Int("league_id", leagueID),
+		log.Int("new_results", s.newResults))
+}
+
+func (s *leagueSync) syncSeasons(retired bool) {
+	name := filepath.Join(s.dir, "seasons")
+	if retired {
+		name = filepath.Join(s.dir, "seasons-retired")
+	}
+	seasons, err := s.api.LeagueSeasons(s.ctx, s.leagueID, retired,
+		irdata.ForceRefresh())
+	if err != nil {
+		log.Error("failed to get league seasons", log.ErrorField(err))
+		util.WriteDriftPayload(name, err)
+		return
+	}
+	util.WriteToFile(name+".json", seasons.Raw())
+	for i := range seasons.Seasons {
+		if s.ctx.Err() != nil {
+			return
+		}
+		s.syncSeason(&seasons.Seasons[i])
+	}
+}
+
+func (s *leagueSync) syncSeason(season *irdata.LeagueSeason) {
+	seasonDir := filepath.Join(s.dir, fmt.Sprintf("season-%d", season.SeasonID))
+	log.Debug("syncing league season",
+		log.Int("season_id", season.SeasonID),
+		log.String("season_name", season.SeasonName))
+
+	sessions, err := s.api.LeagueSeasonSessions(
+		s.ctx, s.leagueID, season.SeasonID, false, irdata.ForceRefresh())
+	if err != nil {
+		log.Error("failed to get league season sessions", log.ErrorField(err))
+		util.WriteDriftPayload(filepath.Join(seasonDir, "sessions"), err)
+		return
+	}
+	util.WriteToFile(filepath.Join(seasonDir, "sessions.json"), sessions.Raw())
+
+	standings, err := s.api.LeagueSeasonStandings(
+		s.ctx, s.leagueID, season.SeasonID, 0, 0, irdata.ForceRefresh())
+	if err != nil {
+		log.Error("failed to get league season standings", log.ErrorField(err))
+		util.WriteDriftPayload(filepath.Join(seasonDir, "standings"), err)
+	} else {
+		s.writeStandings(seasonDir, standings)
+	}
+
+	for i := range sessions.Sessions {
+		sess := sessions.Sessions[i]
+		if !sess.HasResults || sess.SubsessionID == 0 {
+			continue
+		}
+		name := filepath.Join(seasonDir, "results", strconv.Itoa(sess.SubsessionID))
+		if util.FileExists(name + s.format.Ext()) {
+			continue
+		}
+		res, err := s.api.Subsession(s.ctx, sess.SubsessionID, false)
+		if err != nil {
+			log.Error("failed to get subsession results",
+				log.Int("subsession_id", sess.SubsessionID),
+				log.ErrorField(err))
+			util.WriteDriftPayload(name, err)
+			continue
+		}
+		util.WriteData(name, s.format, res.Raw(),
+			func() *export.Table { return export.SubsessionResults(res) })
+		s.newResults++
+	}
+}
+
+// writeStandings writes the standings as delivered for FormatJSON and
+// as separate driver and team tables otherwise
+func (s *leagueSync) writeStandings(
+	dir string,
+	standings *irdata.LeagueSeasonStandingsResponse,
+) {
+	if s.format == export.FormatJSON {
+		util.WriteToFile(filepath.Join(dir, "standings.json"), standings.Raw())
+		return
+	}
+	util.WriteTable(filepath.Join(dir, "standings-drivers"), s.format,
+		export.LeagueDriverStandings(standings))
+	util.WriteTable(filepath.Join(dir, "standings-teams"), s.format,
+		export.LeagueTeamStandings(standings))
+}
@@ -1,9 +1,7 @@
 package populate
 
 import (
-	"os"
-
-	"github.com/mpapenbr/irdata/log"
+	"github.com/mpapenbr/irdata/irdata"
 )
 
 type (
@@ -16,10 +14,17 @@ type (
 	}
 )
 
-func writeToFile(filename string, data []byte) {
-	if err := os.WriteFile(filename, data, 0o600); err != nil {
-		log.Error("failed to write data to file",
-			log.String("filename", filename),
-			log.ErrorField(err))
+// resultWeeks returns the race weeks of the schedules as input for the
+// results command. Weeks with attached qualifying are skipped.
+func resultWeeks(season ResultData, schedules []irdata.Schedule) []ResultData {
+	ret := make([]ResultData, 0, len(schedules))
+	for i := range schedules {
+		if schedules[i].QualAttached {
+			continue
+		}
+		week := season
+		week.RaceWeekNum = schedules[i].RaceWeekNum
+		ret = append(ret, week)
 	}
+	return ret
 }
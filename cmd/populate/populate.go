@@ -4,6 +4,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var format string
+
 func NewPopulateCommand() *cobra.Command {
 	cmd := cobra.Command{
 		Use:   "populate",
@@ -11,7 +13,11 @@ func NewPopulateCommand() *cobra.Command {
 		Long:  ``,
 	}
 
+	cmd.PersistentFlags().StringVar(&format, "format", "json",
+		"output format (json, ndjson, csv, parquet)")
+
 	cmd.AddCommand(NewPopulateSeriesCommand())
 	cmd.AddCommand(NewPopulateResultsCommand())
+	cmd.AddCommand(NewPopulateHistoryCommand())
 	return &cmd
 }
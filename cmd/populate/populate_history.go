@@ -0,0 +1,100 @@
+package populate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mpapenbr/irdata/cmd/util"
+	"github.com/mpapenbr/irdata/export"
+	"github.com/mpapenbr/irdata/irdata"
+	"github.com/mpapenbr/irdata/log"
+)
+
+var historySeries []int
+
+func NewPopulateHistoryCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "history",
+		Short: "populate all past seasons and their schedules of series",
+		Long:  ``,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			f, err := export.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			populateHistory(cmd.Context(), f)
+			return nil
+		},
+	}
+	cmd.PersistentFlags().IntSliceVar(&historySeries, "series", []int{},
+		"iRacing series id")
+	//nolint:errcheck // flag is defined above
+	cmd.MarkPersistentFlagRequired("series")
+
+	return &cmd
+}
+
+func populateHistory(ctx context.Context, f export.Format) {
+	app, err := util.InitApp(irdata.WithRawPayload(true))
+	if err != nil {
+		log.Error("failed to initialize app", log.ErrorField(err))
+		return
+	}
+	defer app.Close()
+	for _, seriesID := range historySeries {
+		name := fmt.Sprintf("tmp/past-seasons-%d", seriesID)
+		pastSeasons, err := app.API.SeriesPastSeasons(ctx, seriesID)
+		if err != nil {
+			log.Error("failed to get past seasons data", log.ErrorField(err))
+			util.WriteDriftPayload(name, err)
+			continue
+		}
+		util.WriteData(name, f, pastSeasons.Raw(),
+			func() *export.Table { return export.PastSeasons(pastSeasons.Series.Seasons) })
+		log.Info("fetched past seasons of series",
+			log.Int("series_id", seriesID),
+			log.String("series_name", pastSeasons.Series.SeriesName),
+			log.Int("season_count", len(pastSeasons.Series.Seasons)))
+
+		results := make([]ResultData, 0)
+		for i := range pastSeasons.Series.Seasons {
+			results = append(results,
+				populateSeasonSchedule(ctx, app, f, &pastSeasons.Series.Seasons[i])...)
+		}
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			log.Error("failed to marshal history results", log.ErrorField(err))
+			continue
+		}
+		util.WriteToFile(fmt.Sprintf("tmp/history-%d.json", seriesID), data)
+	}
+}
+
+// populateSeasonSchedule stores the schedule of the season and returns
+// the race weeks as input for the results command.
+func populateSeasonSchedule(
+	ctx context.Context,
+	app *util.App,
+	f export.Format,
+	s *irdata.PastSeason,
+) []ResultData {
+	name := fmt.Sprintf("tmp/schedule-%d-%d-%d",
+		s.SeasonYear, s.SeasonQuarter, s.SeasonID)
+	schedule, err := app.API.SeasonSchedule(ctx, s.SeasonID)
+	if err != nil {
+		log.Error("failed to get season schedule data", log.ErrorField(err))
+		util.WriteDriftPayload(name, err)
+		return nil
+	}
+	util.WriteData(name, f, schedule.Raw(),
+		func() *export.Table { return export.Schedules(schedule.Schedules) })
+	return resultWeeks(ResultData{
+		SeasonID:      s.SeasonID,
+		SeasonYear:    s.SeasonYear,
+		SeasonQuarter: s.SeasonQuarter,
+		SeasonName:    s.SeasonName,
+	}, schedule.Schedules)
+}
@@ -1,17 +1,26 @@
 package populate
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mpapenbr/irdata/cmd/util"
+	"github.com/mpapenbr/irdata/export"
+	"github.com/mpapenbr/irdata/irdata"
 	"github.com/mpapenbr/irdata/log"
 )
 
-var inputFile string
+var (
+	inputFile       string
+	withSubsessions bool
+	withLaps        bool
+)
 
 func NewPopulateResultsCommand() *cobra.Command {
 	cmd := cobra.Command{
@@ -19,18 +28,33 @@ func NewPopulateResultsCommand() *cobra.Command {
 		Short: "populate results information from iRacing",
 		Long:  ``,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			populateResults()
+			f, err := export.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			populateResults(cmd.Context(), f)
 			return nil
 		},
 	}
 	cmd.PersistentFlags().StringVar(&inputFile, "input-file", "",
 		"Input file for results data")
+	cmd.PersistentFlags().BoolVar(&withSubsessions, "subsessions", false,
+		"also populate the results of each session")
+	cmd.PersistentFlags().BoolVar(&withLaps, "laps", false,
+		"also populate the laps of each race")
 
 	return &cmd
 }
 
-func populateResults() {
-	app, err := util.InitApp()
+type resultsPopulator struct {
+	ctx     context.Context
+	api     *irdata.IrData
+	format  export.Format
+	catalog *irdata.Catalog // names of cars and tracks (nil if not available)
+}
+
+func populateResults(ctx context.Context, f export.Format) {
+	app, err := util.InitApp(irdata.WithRawPayload(true))
 	if err != nil {
 		log.Error("failed to initialize app", log.ErrorField(err))
 		return
@@ -43,24 +67,84 @@ func populateResults() {
 	}
 
 	var results []ResultData
-	if err := json.Unmarshal(data, &results); err != nil {
+	if err = json.Unmarshal(data, &results); err != nil {
 		log.Error("failed to parse results data", log.ErrorField(err))
 		return
 	}
 	log.Info("successfully parsed results data", log.Int("num_results", len(results)))
+	p := &resultsPopulator{ctx: ctx, api: app.API, format: f}
+	if withSubsessions {
+		if p.catalog, err = app.API.LoadCatalog(ctx); err != nil {
+			log.Warn("failed to load catalog, names are not enriched",
+				log.ErrorField(err))
+		}
+	}
 	for i := range results {
-		r := results[i]
-		var data []byte
-		var err error
-		// just races
-		data, err = app.API.Get(
-			fmt.Sprintf(`
-			/data/results/season_results?season_id=%d&race_week_num=%d&event_type=5
-			`, r.SeasonID, r.RaceWeekNum))
+		p.populateWeek(&results[i])
+	}
+}
+
+// populateWeek stores the race sessions of a race week
+func (p *resultsPopulator) populateWeek(r *ResultData) {
+	name := weekFile(p.format, r, "results", "results",
+		fmt.Sprintf("results-%d-%d", r.SeasonID, r.RaceWeekNum))
+	resp, err := p.api.SeasonResults(p.ctx, r.SeasonID, r.RaceWeekNum,
+		irdata.EventTypeRace)
+	if err != nil {
+		log.Error("failed to get current season data", log.ErrorField(err))
+		util.WriteDriftPayload(name, err)
+		return
+	}
+	util.WriteData(name, p.format, resp.Raw(),
+		func() *export.Table { return export.SeasonResults(&resp.Data) })
+	for j := range resp.Data.ResultsList {
+		p.populateSession(r, resp.Data.ResultsList[j].SubsessionID)
+	}
+}
+
+// populateSession stores the results and laps of a session if requested
+func (p *resultsPopulator) populateSession(r *ResultData, subsessionID int) {
+	id := strconv.Itoa(subsessionID)
+	if withSubsessions {
+		name := weekFile(p.format, r, "subsessions", id, "subsession-"+id)
+		res, err := p.api.Subsession(p.ctx, subsessionID, false)
+		if err != nil {
+			log.Error("failed to get subsession results",
+				log.Int("subsession_id", subsessionID),
+				log.ErrorField(err))
+			util.WriteDriftPayload(name, err)
+		} else {
+			util.WriteData(name, p.format, res.Raw(),
+				func() *export.Table {
+					if p.catalog != nil {
+						p.catalog.EnrichSubsession(res)
+					}
+					return export.SubsessionResults(res)
+				})
+		}
+	}
+	if withLaps {
+		// laps of all drivers of the race
+		laps, err := p.api.LapChartData(p.ctx, subsessionID, 0)
 		if err != nil {
-			log.Error("failed to get current season data", log.ErrorField(err))
-			continue
+			log.Error("failed to get lap data",
+				log.Int("subsession_id", subsessionID),
+				log.ErrorField(err))
+			return
 		}
-		writeToFile(fmt.Sprintf("tmp/results-%d-%d.json", r.SeasonID, r.RaceWeekNum), data)
+		// there is no single payload for lap data, json is written as table
+		util.WriteTable(weekFile(p.format, r, "laps", id, "laps-"+id),
+			p.format, export.Laps(&laps.SessionInfo, laps.Laps))
+	}
+}
+
+// weekFile returns the output file (without extension) of data of the race
+// week r. Parquet files are stored as file in the season/week partition of
+// the dataset, the other formats use the flat name.
+func weekFile(f export.Format, r *ResultData, dataset, file, flat string) string {
+	if f == export.FormatParquet {
+		dir := export.PartitionDir(filepath.Join("tmp", dataset), r.SeasonID, r.RaceWeekNum)
+		return filepath.Join(dir, file)
 	}
+	return filepath.Join("tmp", flat)
 }
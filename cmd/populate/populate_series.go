@@ -1,12 +1,14 @@
 package populate
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mpapenbr/irdata/cmd/util"
+	"github.com/mpapenbr/irdata/export"
 	"github.com/mpapenbr/irdata/irdata"
 	"github.com/mpapenbr/irdata/log"
 )
@@ -22,7 +24,11 @@ func NewPopulateSeriesCommand() *cobra.Command {
 		Short: "populate series information from iRacing",
 		Long:  ``,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			populateSeries()
+			f, err := export.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			populateSeries(cmd.Context(), f)
 			return nil
 		},
 	}
@@ -35,8 +41,8 @@ func NewPopulateSeriesCommand() *cobra.Command {
 }
 
 //nolint:funlen // showcase
-func populateSeries() {
-	app, err := util.InitApp()
+func populateSeries(ctx context.Context, f export.Format) {
+	app, err := util.InitApp(irdata.WithRawPayload(true))
 	if err != nil {
 		log.Error("failed to initialize app", log.ErrorField(err))
 		return
@@ -48,26 +54,20 @@ func populateSeries() {
 	results := make([]ResultData, 0)
 	for _, y := range year {
 		for _, q := range quarter {
-			var data []byte
-			var err error
-			data, err = app.API.Get(
-				fmt.Sprintf("/data/series/season_list?season_year=%d&season_quarter=%d",
-					y, q))
+			name := fmt.Sprintf("tmp/season-%d-%d", y, q)
+			seasons, err := app.API.SeasonList(ctx, y, q)
 			if err != nil {
 				log.Error("failed to get current season data", log.ErrorField(err))
+				util.WriteDriftPayload(name, err)
 				continue
 			}
 			log.Info("fetched series data for year and quarter",
 				log.Int("year", y),
 				log.Int("quarter", q),
 				log.Int("data-size",
-					len(data)))
-			writeToFile(fmt.Sprintf("tmp/season-%d-%d.json", y, q), data)
-			var seasons irdata.SeasonList
-			if err = json.Unmarshal(data, &seasons); err != nil {
-				log.Error("failed to unmarshal season data", log.ErrorField(err))
-				continue
-			}
+					len(seasons.Raw())))
+			util.WriteData(name, f, seasons.Raw(),
+				func() *export.Table { return export.Seasons(seasons.Seasons) })
 
 			for i := range seasons.Seasons {
 				s := seasons.Seasons[i]
@@ -77,34 +77,21 @@ func populateSeries() {
 					log.Int("season_year", s.SeasonYear),
 					log.Int("season_quarter", s.SeasonQuarter),
 				)
-				data, err = app.API.Get(
-					fmt.Sprintf("/data/series/season_schedule?season_id=%d", s.SeasonID))
+				name := fmt.Sprintf("tmp/schedule-%d-%d-%d", y, q, s.SeasonID)
+				schedule, err := app.API.SeasonSchedule(ctx, s.SeasonID)
 				if err != nil {
 					log.Error("failed to get season schedule data", log.ErrorField(err))
+					util.WriteDriftPayload(name, err)
 					continue
 				}
-				writeToFile(
-					fmt.Sprintf("tmp/schedule-%d-%d-%d.json", y, q, s.SeasonID),
-					data,
-				)
-				var schedule irdata.ScheduleResponse
-				if err = json.Unmarshal(data, &schedule); err != nil {
-					log.Error("failed to unmarshal season schedule data",
-						log.ErrorField(err))
-					continue
-				}
-				for i := range schedule.Schedules {
-					r := schedule.Schedules[i]
-					if !r.QualAttached {
-						results = append(results, ResultData{
-							SeasonID:      s.SeasonID,
-							SeasonYear:    s.SeasonYear,
-							SeasonQuarter: s.SeasonQuarter,
-							SeasonName:    s.SeasonName,
-							RaceWeekNum:   r.RaceWeekNum,
-						})
-					}
-				}
+				util.WriteData(name, f, schedule.Raw(),
+					func() *export.Table { return export.Schedules(schedule.Schedules) })
+				results = append(results, resultWeeks(ResultData{
+					SeasonID:      s.SeasonID,
+					SeasonYear:    s.SeasonYear,
+					SeasonQuarter: s.SeasonQuarter,
+					SeasonName:    s.SeasonName,
+				}, schedule.Schedules)...)
 			}
 			log.Info("season data", log.Int("season_count", len(seasons.Seasons)))
 		}
@@ -115,6 +102,6 @@ func populateSeries() {
 			log.Error("failed to marshal final results", log.ErrorField(err))
 			return
 		}
-		writeToFile("tmp/00-detached-quali.json", data)
+		util.WriteToFile("tmp/00-detached-quali.json", data)
 	}
 }
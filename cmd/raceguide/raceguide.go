@@ -0,0 +1,153 @@
+package raceguide
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mpapenbr/irdata/cmd/util"
+	"github.com/mpapenbr/irdata/irdata"
+	"github.com/mpapenbr/irdata/log"
+)
+
+var (
+	seriesIDs     []int
+	categories    []string
+	licenseGroups []int
+	within        time.Duration
+	watch         bool
+)
+
+func NewRaceGuideCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "raceguide",
+		Short: "show upcoming official sessions",
+		Long:  ``,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter, err := buildFilter()
+			if err != nil {
+				return err
+			}
+			showRaceGuide(cmd.Context(), filter)
+			return nil
+		},
+	}
+	cmd.Flags().IntSliceVar(&seriesIDs, "series-id", []int{},
+		"restrict to these series ids")
+	cmd.Flags().StringSliceVar(&categories, "category", []string{},
+		"restrict to these categories (oval, sports_car, formula_car, dirt_oval, ...)")
+	cmd.Flags().IntSliceVar(&licenseGroups, "license-group", []int{},
+		"restrict to these license groups (1=Rookie, 2=D, 3=C, 4=B, 5=A)")
+	cmd.Flags().DurationVar(&within, "within", 0,
+		"only show sessions starting within this duration")
+	cmd.Flags().BoolVar(&watch, "watch", false,
+		"refresh the race guide periodically")
+
+	return &cmd
+}
+
+func buildFilter() (*irdata.RaceGuideFilter, error) {
+	f := &irdata.RaceGuideFilter{
+		SeriesIDs:     seriesIDs,
+		LicenseGroups: licenseGroups,
+		Within:        within,
+	}
+	for _, c := range categories {
+		cat, ok := irdata.CategoryFromTrackType(c)
+		if !ok {
+			return nil, fmt.Errorf("unknown category %q", c)
+		}
+		f.Categories = append(f.Categories, cat)
+	}
+	return f, nil
+}
+
+func showRaceGuide(ctx context.Context, filter *irdata.RaceGuideFilter) {
+	app, err := util.InitApp()
+	if err != nil {
+		log.Error("failed to initialize app", log.ErrorField(err))
+		return
+	}
+	defer app.Close()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	seasons := currentSeasons(ctx, app.API, time.Now())
+	for {
+		now := time.Now()
+		resp, err := app.API.RaceGuide(ctx,
+			now.Truncate(irdata.RaceGuideCacheInterval), false)
+		if err != nil {
+			log.Error("failed to get race guide", log.ErrorField(err))
+		} else {
+			if watch {
+				fmt.Fprint(os.Stdout, "\033[H\033[2J")
+			}
+			g := irdata.NewRaceGuide(resp, seasons)
+			printTable(os.Stdout, g.Filter(filter, now), now)
+		}
+		if !watch {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(irdata.RaceGuideCacheInterval):
+		}
+	}
+}
+
+// currentSeasons returns the seasons of the current and the next quarter.
+// The next quarter is included because new seasons start before the
+// calendar quarter begins.
+func currentSeasons(
+	ctx context.Context,
+	api *irdata.IrData,
+	now time.Time,
+) []irdata.Season {
+	ret := []irdata.Season{}
+	year, quarter := now.Year(), int(now.Month()-1)/3+1
+	for range 2 {
+		list, err := api.SeasonList(ctx, year, quarter)
+		if err != nil {
+			log.Warn("failed to get season list",
+				log.Int("year", year),
+				log.Int("quarter", quarter),
+				log.ErrorField(err))
+		} else {
+			ret = append(ret, list.Seasons...)
+		}
+		if quarter++; quarter > 4 {
+			year, quarter = year+1, 1
+		}
+	}
+	return ret
+}
+
+func printTable(out io.Writer, entries []irdata.RaceGuideEntry, now time.Time) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "START\tIN\tSERIES\tWEEK\tENTRIES\tSESSION")
+	for i := range entries {
+		e := &entries[i]
+		name := e.SeriesName
+		if name == "" {
+			name = fmt.Sprintf("series %d", e.SeriesID)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\n",
+			e.StartTime.Local().Format("15:04"),
+			e.TimeToStart(now).Truncate(time.Minute),
+			name,
+			e.RaceWeekNum+1,
+			e.EntryCount,
+			e.SessionID)
+	}
+	//nolint:errcheck // by design
+	w.Flush()
+}
@@ -0,0 +1,16 @@
+package records
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewRecordsCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "records",
+		Short: "commands related to world records and time attack",
+		Long:  ``,
+	}
+
+	cmd.AddCommand(NewRecordsMatrixCommand())
+	return &cmd
+}
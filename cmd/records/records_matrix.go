@@ -0,0 +1,165 @@
+package records
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mpapenbr/irdata/cmd/util"
+	"github.com/mpapenbr/irdata/irdata"
+	"github.com/mpapenbr/irdata/log"
+)
+
+var (
+	year      int
+	carIDs    []int
+	trackIDs  []int
+	seriesIDs []int
+	outputDir string
+)
+
+func NewRecordsMatrixCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "matrix",
+		Short: "build a car x track matrix of world records",
+		Long: `Builds a matrix of the best laps for each car/track combination of a year.
+Cars and tracks are either given explicitly or collected from the seasons
+of the given series in that year. The matrix is stored as JSON and CSV.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(seriesIDs) == 0 && (len(carIDs) == 0 || len(trackIDs) == 0) {
+				return fmt.Errorf("either --series or --car-id and --track-id required")
+			}
+			buildMatrix(cmd.Context())
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&year, "year", time.Now().Year(), "season year")
+	cmd.Flags().IntSliceVar(&carIDs, "car-id", []int{}, "car ids")
+	cmd.Flags().IntSliceVar(&trackIDs, "track-id", []int{}, "track ids")
+	cmd.Flags().IntSliceVar(&seriesIDs, "series", []int{},
+		"collect cars and tracks from the seasons of these series")
+	cmd.Flags().StringVar(&outputDir, "output-dir", "tmp",
+		"directory to store the matrix files")
+
+	return &cmd
+}
+
+func buildMatrix(ctx context.Context) {
+	app, err := util.InitApp()
+	if err != nil {
+		log.Error("failed to initialize app", log.ErrorField(err))
+		return
+	}
+	defer app.Close()
+
+	cars, err := app.API.Cars(ctx)
+	if err != nil {
+		log.Error("failed to get cars", log.ErrorField(err))
+		return
+	}
+	carClasses, err := app.API.CarClasses(ctx)
+	if err != nil {
+		log.Error("failed to get car classes", log.ErrorField(err))
+		return
+	}
+	tracks, err := app.API.Tracks(ctx)
+	if err != nil {
+		log.Error("failed to get tracks", log.ErrorField(err))
+		return
+	}
+	catalog := irdata.NewCatalog(cars, carClasses, tracks)
+	for _, seriesID := range seriesIDs {
+		collectFromSeries(ctx, app.API, catalog, seriesID)
+	}
+	slices.Sort(carIDs)
+	slices.Sort(trackIDs)
+	carIDs, trackIDs = slices.Compact(carIDs), slices.Compact(trackIDs)
+
+	log.Info("building records matrix",
+		log.Int("year", year),
+		log.Int("cars", len(carIDs)),
+		log.Int("tracks", len(trackIDs)))
+	m, err := app.API.WorldRecordsMatrix(ctx, year, carIDs, trackIDs)
+	if err != nil {
+		log.Error("failed to build records matrix", log.ErrorField(err))
+		return
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		log.Error("failed to marshal records matrix", log.ErrorField(err))
+		return
+	}
+	util.WriteToFile(
+		filepath.Join(outputDir, fmt.Sprintf("records-%d.json", year)), data)
+	writeCSV(m, catalog)
+}
+
+// collectFromSeries adds the cars and tracks of the series seasons in year
+func collectFromSeries(
+	ctx context.Context,
+	api *irdata.IrData,
+	catalog *irdata.Catalog,
+	seriesID int,
+) {
+	past, err := api.SeriesPastSeasons(ctx, seriesID)
+	if err != nil {
+		log.Error("failed to get past seasons",
+			log.Int("series_id", seriesID),
+			log.ErrorField(err))
+		return
+	}
+	for i := range past.Series.Seasons {
+		s := &past.Series.Seasons[i]
+		if s.SeasonYear != year {
+			continue
+		}
+		for j := range s.RaceWeeks {
+			trackIDs = append(trackIDs, s.RaceWeeks[j].Track.TrackID)
+		}
+		for j := range s.CarClasses {
+			cc, ok := catalog.CarClasses[s.CarClasses[j].CarClassID]
+			if !ok {
+				continue
+			}
+			for k := range cc.CarsInClass {
+				carIDs = append(carIDs, cc.CarsInClass[k].CarID)
+			}
+		}
+	}
+}
+
+func writeCSV(m *irdata.RecordsMatrix, catalog *irdata.Catalog) {
+	header := []string{"car_id", "car_name"}
+	for _, trackID := range m.TrackIDs {
+		header = append(header, catalog.TrackName(trackID))
+	}
+	rows := [][]string{header}
+	cells := m.CellIndex()
+	for _, carID := range m.CarIDs {
+		row := []string{strconv.Itoa(carID), catalog.CarName(carID)}
+		for _, trackID := range m.TrackIDs {
+			value := ""
+			if c := cells[[2]int{carID, trackID}]; c != nil && c.LapTime.Valid() {
+				value = c.LapTime.String()
+			}
+			row = append(row, value)
+		}
+		rows = append(rows, row)
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(rows); err != nil {
+		log.Error("failed to create csv data", log.ErrorField(err))
+		return
+	}
+	util.WriteToFile(
+		filepath.Join(outputDir, fmt.Sprintf("records-%d.csv", year)), buf.Bytes())
+}
@@ -15,8 +15,16 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/mpapenbr/irdata/cmd/auth"
+	"github.com/mpapenbr/irdata/cmd/cache"
+	"github.com/mpapenbr/irdata/cmd/catalog"
 	"github.com/mpapenbr/irdata/cmd/config"
+	"github.com/mpapenbr/irdata/cmd/hosted"
+	"github.com/mpapenbr/irdata/cmd/league"
 	"github.com/mpapenbr/irdata/cmd/populate"
+	"github.com/mpapenbr/irdata/cmd/raceguide"
+	"github.com/mpapenbr/irdata/cmd/records"
+	"github.com/mpapenbr/irdata/cmd/schema"
+	"github.com/mpapenbr/irdata/cmd/team"
 	"github.com/mpapenbr/irdata/log"
 	"github.com/mpapenbr/irdata/otel"
 	"github.com/mpapenbr/irdata/version"
@@ -123,6 +131,35 @@ func init() {
 		"if true, don't log fields that contain a context.Context")
 	rootCmd.PersistentFlags().StringVar(&config.CacheDir, "cache-dir",
 		"", "directory to store cache files")
+	rootCmd.PersistentFlags().StringVar(&config.CacheBackend, "cache-backend",
+		"badger", "cache backend (fs, badger, sqlite, none)")
+	rootCmd.PersistentFlags().BoolVar(&config.CacheCompress, "cache-compress",
+		false, "gzip compress cache files (fs backend only)")
+	rootCmd.PersistentFlags().StringVar(&config.CacheCodec, "cache-codec",
+		"zstd", "compression of new cache entries: none, gzip, zstd (badger only)")
+	rootCmd.PersistentFlags().DurationVar(&config.CacheTTL, "cache-ttl",
+		0, "time to live of cache entries, 0 means no expiry (fs and sqlite only)")
+	rootCmd.PersistentFlags().Int64Var(&config.MemoryCacheSize, "memory-cache-size",
+		64<<20, "size of the in-memory cache in front of the cache backend in bytes "+
+			"(0 disables it)")
+	rootCmd.PersistentFlags().StringVar(&config.DecodeMode, "decode-mode",
+		"lenient", "how to handle unknown fields in responses (lenient, report, strict)")
+	rootCmd.PersistentFlags().BoolVar(&config.CacheLinks, "cache-links",
+		false, "keep S3 links in memory until they expire")
+	rootCmd.PersistentFlags().Int64Var(&config.MaxPayloadSize, "max-payload-size",
+		0, "max size of a response payload in bytes (0: no limit)")
+	rootCmd.PersistentFlags().BoolVar(&config.Offline, "offline",
+		false, "serve data from the cache only, never access the network")
+	rootCmd.PersistentFlags().BoolVar(&config.ServeStale, "serve-stale",
+		true, "serve expired cache entries if iRacing is not available "+
+			"(requires a cache backend keeping entry metadata (cache.EntryReader): "+
+			"badger, fs, sqlite)")
+	rootCmd.PersistentFlags().BoolVar(&config.NoCache, "no-cache",
+		false, "neither read from nor write to the cache")
+	rootCmd.PersistentFlags().BoolVar(&config.Refresh, "refresh",
+		false, "fetch data even if cached and overwrite the cache entries")
+	rootCmd.PersistentFlags().DurationVar(&config.MaxAge, "max-age",
+		0, "fetch data if the cache entry is older (0: no limit)")
 
 	rootCmd.PersistentFlags().StringVar(&config.IrAuthConfig.ClientID,
 		"client-id", "", "iRacing API client ID")
@@ -137,6 +174,14 @@ func init() {
 	rootCmd.AddCommand(auth.NewAuthCommand())
 
 	rootCmd.AddCommand(populate.NewPopulateCommand())
+	rootCmd.AddCommand(league.NewLeagueCommand())
+	rootCmd.AddCommand(catalog.NewCatalogCommand())
+	rootCmd.AddCommand(raceguide.NewRaceGuideCommand())
+	rootCmd.AddCommand(hosted.NewHostedCommand())
+	rootCmd.AddCommand(team.NewTeamCommand())
+	rootCmd.AddCommand(records.NewRecordsCommand())
+	rootCmd.AddCommand(schema.NewSchemaCommand())
+	rootCmd.AddCommand(cache.NewCacheCommand())
 	// add commands here
 	// e.g. rootCmd.AddCommand(sampleCmd.NewSampleCmd())
 }
@@ -0,0 +1,16 @@
+package schema
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewSchemaCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "schema",
+		Short: "commands related to the response schemas of the data API",
+		Long:  ``,
+	}
+
+	cmd.AddCommand(NewSchemaCheckCommand())
+	return &cmd
+}
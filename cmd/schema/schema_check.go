@@ -0,0 +1,103 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mpapenbr/irdata/cache"
+	"github.com/mpapenbr/irdata/cmd/util"
+	"github.com/mpapenbr/irdata/irdata"
+	"github.com/mpapenbr/irdata/log"
+)
+
+var endpointPrefix string
+
+func NewSchemaCheckCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "check",
+		Short: "check cached responses for fields not covered by the typed models",
+		Long: `Decodes all cached responses of endpoints with a typed model and reports
+the fields that are not covered by the model (schema drift).
+No requests to the data API are made.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checkSchema()
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&endpointPrefix, "endpoint", "/data/",
+		"only check endpoints starting with this prefix")
+
+	return &cmd
+}
+
+func checkSchema() {
+	app, err := util.InitCache()
+	if err != nil {
+		log.Error("failed to initialize cache", log.ErrorField(err))
+		return
+	}
+	defer app.Close()
+
+	it, ok := app.Cache.(cache.Iterable)
+	if !ok {
+		log.Error("cache does not support iteration")
+		return
+	}
+	drift := map[string]map[string]struct{}{}
+	checked := 0
+	err = it.Iterate(endpointPrefix, func(key string, value []byte) error {
+		u, parseErr := url.Parse(key)
+		if parseErr != nil {
+			log.Warn("skipping invalid cache key", log.String("key", key))
+			return nil
+		}
+		fields, checkErr := irdata.CheckSchema(u.Path, value)
+		if errors.Is(checkErr, irdata.ErrUnknownEndpoint) {
+			return nil
+		}
+		if checkErr != nil {
+			log.Warn("failed to check cache entry",
+				log.String("key", key), log.ErrorField(checkErr))
+			return nil
+		}
+		checked++
+		for _, f := range fields {
+			if drift[u.Path] == nil {
+				drift[u.Path] = map[string]struct{}{}
+			}
+			drift[u.Path][f] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to iterate cache", log.ErrorField(err))
+		return
+	}
+	printDrift(checked, drift)
+}
+
+func printDrift(checked int, drift map[string]map[string]struct{}) {
+	fmt.Printf("checked %d cached responses\n", checked)
+	if len(drift) == 0 {
+		fmt.Println("no schema drift found")
+		return
+	}
+	endpoints := make([]string, 0, len(drift))
+	for k := range drift {
+		endpoints = append(endpoints, k)
+	}
+	slices.Sort(endpoints)
+	for _, endpoint := range endpoints {
+		fields := make([]string, 0, len(drift[endpoint]))
+		for f := range drift[endpoint] {
+			fields = append(fields, f)
+		}
+		slices.Sort(fields)
+		fmt.Printf("%s:\n  %s\n", endpoint, strings.Join(fields, "\n  "))
+	}
+}
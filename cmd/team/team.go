@@ -0,0 +1,139 @@
+package team
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mpapenbr/irdata/cmd/util"
+	"github.com/mpapenbr/irdata/irdata"
+	"github.com/mpapenbr/irdata/log"
+)
+
+var (
+	since      string
+	outputFile string
+)
+
+// NewTeamCommand provides "team <team_id> results".
+// The team id precedes the action, so the action is handled as argument.
+func NewTeamCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:       "team <team_id> results",
+		Short:     "commands related to teams",
+		Long:      ``,
+		ValidArgs: []string{"results"},
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+				return err
+			}
+			if args[1] != "results" {
+				return fmt.Errorf("unknown action %q (results)", args[1])
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			teamID, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid team id %q: %w", args[0], err)
+			}
+			// full hours keep the search params (and cache keys) stable
+			// between runs
+			now := time.Now().Truncate(time.Hour)
+			sinceTime, err := parseSince(since, now)
+			if err != nil {
+				return err
+			}
+			teamResults(cmd.Context(), teamID, sinceTime, now)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&since, "since", "720h",
+		"start of the results (date like 2026-01-31 or duration like 720h). "+
+			"Results are searched up to the start of the current hour.")
+	cmd.Flags().StringVar(&outputFile, "output-file", "",
+		"write the results as JSON to this file")
+
+	return &cmd
+}
+
+func parseSince(arg string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(arg); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, arg, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid value for --since %q", arg)
+	}
+	return t, nil
+}
+
+func teamResults(ctx context.Context, teamID int, sinceTime, until time.Time) {
+	app, err := util.InitApp()
+	if err != nil {
+		log.Error("failed to initialize app", log.ErrorField(err))
+		return
+	}
+	defer app.Close()
+
+	res, err := app.API.TeamResults(ctx, teamID, sinceTime, until)
+	if err != nil {
+		log.Error("failed to get team results", log.ErrorField(err))
+		return
+	}
+	if outputFile != "" {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			log.Error("failed to marshal team results", log.ErrorField(err))
+			return
+		}
+		util.WriteToFile(outputFile, data)
+	}
+	printTable(os.Stdout, res)
+}
+
+func printTable(out io.Writer, res *irdata.TeamResults) {
+	fmt.Fprintf(out, "%s (%d results)\n", res.Team.TeamName, len(res.Results))
+	for i := range res.Leagues {
+		l := &res.Leagues[i]
+		members := make([]string, len(l.Members))
+		for j := range l.Members {
+			members[j] = l.Members[j].DisplayName
+		}
+		fmt.Fprintf(out, "league %s (%d): %s\n",
+			l.LeagueName, l.LeagueID, strings.Join(members, ", "))
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w,
+		"DATE\tSUBSESSION\tSERIES/SESSION\tTRACK\tCAR\tSTART\tFINISH\tDRIVERS")
+	for i := range res.Results {
+		r := &res.Results[i]
+		name := r.SeriesName
+		if name == "" {
+			name = r.SessionName
+		}
+		drivers := make([]string, len(r.Drivers))
+		for j := range r.Drivers {
+			drivers[j] = r.Drivers[j].DisplayName
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			r.StartTime.Local().Format("2006-01-02 15:04"),
+			r.SubsessionID,
+			name,
+			r.Track.FullName(),
+			r.CarName,
+			r.StartingPosition+1,
+			r.FinishPosition+1,
+			strings.Join(drivers, ", "))
+	}
+	//nolint:errcheck // by design
+	w.Flush()
+}
@@ -0,0 +1,75 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/mpapenbr/irdata/export"
+	"github.com/mpapenbr/irdata/irdata"
+	"github.com/mpapenbr/irdata/log"
+)
+
+// WriteToFile writes data to filename. Missing parent directories are created.
+// Errors are logged, not returned.
+func WriteToFile(filename string, data []byte) {
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		log.Error("failed to create directory",
+			log.String("filename", filename),
+			log.ErrorField(err))
+		return
+	}
+	if err := os.WriteFile(filename, data, 0o600); err != nil {
+		log.Error("failed to write data to file",
+			log.String("filename", filename),
+			log.ErrorField(err))
+	}
+}
+
+// FileExists reports whether filename exists.
+func FileExists(filename string) bool {
+	_, err := os.Stat(filename)
+	return err == nil
+}
+
+// WriteTable writes t in format f to filename. The extension of the format
+// is appended to filename. Errors are logged, not returned.
+func WriteTable(filename string, f export.Format, t *export.Table) {
+	var buf bytes.Buffer
+	if err := export.Write(&buf, f, t); err != nil {
+		log.Error("failed to create table data",
+			log.String("filename", filename),
+			log.ErrorField(err))
+		return
+	}
+	WriteToFile(filename+f.Ext(), buf.Bytes())
+}
+
+// WriteData writes the raw payload to filename for FormatJSON and the table
+// in format f otherwise. table is only called for other formats. The extension
+// of the format is appended to filename. Responses rejected due to schema
+// drift have no table, their payload is written with WriteDriftPayload.
+func WriteData(
+	filename string,
+	f export.Format,
+	raw []byte,
+	table func() *export.Table,
+) {
+	if f == export.FormatJSON {
+		WriteToFile(filename+f.Ext(), raw)
+		return
+	}
+	WriteTable(filename, f, table())
+}
+
+// WriteDriftPayload writes the payload of a response rejected due to schema
+// drift (see irdata.DecodeStrict) as JSON to filename, so the upstream data
+// is kept. The extension of FormatJSON is appended to filename. Nothing is
+// written for other errors.
+func WriteDriftPayload(filename string, err error) {
+	var drift *irdata.SchemaDriftError
+	if errors.As(err, &drift) && drift.Payload != nil {
+		WriteToFile(filename+export.FormatJSON.Ext(), drift.Payload)
+	}
+}
@@ -1,6 +1,11 @@
 package util
 
 import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+
 	"github.com/dgraph-io/badger/v4"
 
 	"github.com/mpapenbr/irdata/auth"
@@ -12,12 +17,77 @@ import (
 
 type (
 	App struct {
-		API *irdata.IrData
-		DB  *badger.DB
+		API   *irdata.IrData
+		DB    *badger.DB
+		SQL   *sql.DB
+		Cache cache.Cache
+
+		memory cache.Cache // memory tier in front of Cache (nil if disabled)
 	}
 )
 
-func InitApp() (*App, error) {
+// InitApp logs in and creates the API client using the cache.
+// opts are applied after the default options.
+// In offline mode no login is performed.
+func InitApp(opts ...irdata.Option) (*App, error) {
+	tp, tpErr := initTokenProvider()
+	if tpErr != nil {
+		return nil, tpErr
+	}
+	decodeMode, modeErr := irdata.ParseDecodeMode(config.DecodeMode)
+	if modeErr != nil {
+		log.Error("invalid decode mode", log.ErrorField(modeErr))
+		return nil, modeErr
+	}
+	app, err := InitCache()
+	if err != nil {
+		return nil, err
+	}
+	if config.MemoryCacheSize > 0 {
+		app.memory = cache.NewMemoryCache(config.MemoryCacheSize, app.Cache)
+		app.Cache = app.memory
+	}
+	ir, irErr := irdata.NewIrData(append([]irdata.Option{
+		irdata.WithTokenProvider(tp),
+		irdata.WithCache(app.Cache),
+		irdata.WithDecodeMode(decodeMode),
+		irdata.WithLinkCache(config.CacheLinks),
+		irdata.WithMaxPayloadSize(config.MaxPayloadSize),
+		irdata.WithOffline(config.Offline),
+		irdata.WithServeStale(config.ServeStale),
+		irdata.WithCallOptions(callOptions()...),
+	}, opts...)...)
+	if irErr != nil {
+		log.Error("failed to create iRData instance", log.ErrorField(irErr))
+		app.Close()
+		return nil, irErr
+	}
+	app.API = ir
+	return app, nil
+}
+
+// callOptions returns the default call options according to the config.
+// CacheOnly is covered by the offline mode.
+func callOptions() []irdata.CallOption {
+	var ret []irdata.CallOption
+	if config.NoCache {
+		ret = append(ret, irdata.NoCache())
+	}
+	if config.Refresh {
+		ret = append(ret, irdata.ForceRefresh())
+	}
+	if config.MaxAge > 0 {
+		ret = append(ret, irdata.MaxAge(config.MaxAge))
+	}
+	return ret
+}
+
+// initTokenProvider logs in and returns the token provider.
+// In offline mode a provider failing with irdata.ErrOffline is returned.
+func initTokenProvider() (irdata.TokenProvider, error) {
+	if config.Offline {
+		return func() (string, error) { return "", irdata.ErrOffline }, nil
+	}
 	tm, tmErr := auth.NewTokenManager(auth.WithAuthConfig(&config.IrAuthConfig))
 	if tmErr != nil {
 		log.Error("failed to create token manager", log.ErrorField(tmErr))
@@ -27,30 +97,97 @@ func InitApp() (*App, error) {
 		log.Error("failed to login", log.ErrorField(loginErr))
 		return nil, loginErr
 	}
+	return tm.GetAccessToken, nil
+}
+
+// InitCache opens the cache only. The API of the returned App is nil.
+func InitCache() (*App, error) {
+	switch config.CacheBackend {
+	case "badger":
+		return initBadgerCache()
+	case "fs":
+		c, err := cache.NewFileCache(config.CacheDir,
+			cache.WithCompression(config.CacheCompress),
+			cache.WithTTL(config.CacheTTL))
+		if err != nil {
+			log.Error("failed to create cache", log.ErrorField(err))
+			return nil, err
+		}
+		return &App{Cache: c}, nil
+	case "sqlite":
+		return initSQLiteCache()
+	case "none":
+		return &App{Cache: cache.NewNoopCache()}, nil
+	default:
+		err := fmt.Errorf("unknown cache backend: %s", config.CacheBackend)
+		log.Error("failed to create cache", log.ErrorField(err))
+		return nil, err
+	}
+}
+
+func initSQLiteCache() (*App, error) {
+	if err := os.MkdirAll(config.CacheDir, 0o755); err != nil {
+		log.Error("failed to create cache directory", log.ErrorField(err))
+		return nil, err
+	}
+	db, dbErr := cache.OpenSQLite(filepath.Join(config.CacheDir, "irdata.sqlite"))
+	if dbErr != nil {
+		log.Error("failed to open cache database", log.ErrorField(dbErr))
+		return nil, dbErr
+	}
+	c, cacheErr := cache.NewSQLiteCache(db, cache.WithSQLiteTTL(config.CacheTTL))
+	if cacheErr != nil {
+		log.Error("failed to create cache", log.ErrorField(cacheErr))
+		//nolint:errcheck // already failing
+		db.Close()
+		return nil, cacheErr
+	}
+	return &App{SQL: db, Cache: c}, nil
+}
+
+func initBadgerCache() (*App, error) {
 	db, dbErr := badger.Open(badger.DefaultOptions(config.CacheDir))
 	if dbErr != nil {
 		log.Error("failed to open cache database", log.ErrorField(dbErr))
 		return nil, dbErr
 	}
 
-	badgerCache, cacheErr := cache.NewBadgerCache(db)
+	codec, codecErr := cache.ParseCodec(config.CacheCodec)
+	if codecErr != nil {
+		log.Error("invalid cache codec", log.ErrorField(codecErr))
+		//nolint:errcheck // already failing
+		db.Close()
+		return nil, codecErr
+	}
+	badgerCache, cacheErr := cache.NewBadgerCache(db, cache.WithCodec(codec))
 	if cacheErr != nil {
 		log.Error("failed to create cache", log.ErrorField(cacheErr))
+		//nolint:errcheck // already failing
+		db.Close()
 		return nil, cacheErr
 	}
-	ir, irErr := irdata.NewIrData(
-		irdata.WithTokenProvider(tm.GetAccessToken),
-		irdata.WithCache(badgerCache),
-	)
-	if irErr != nil {
-		log.Error("failed to create iRData instance", log.ErrorField(irErr))
-		return nil, irErr
-	}
-	return &App{API: ir, DB: db}, nil
+	return &App{DB: db, Cache: badgerCache}, nil
 }
 
 func (a *App) Close() {
-	if err := a.DB.Close(); err != nil {
-		log.Error("failed to close cache database", log.ErrorField(err))
+	// the stats of the other backends may require a scan of all entries
+	if sp, ok := a.memory.(cache.StatsProvider); ok {
+		stats := sp.Stats()
+		log.Debug("memory cache stats",
+			log.Uint64("hits", stats.Hits),
+			log.Uint64("misses", stats.Misses),
+			log.Uint64("evictions", stats.Evictions),
+			log.Int("entries", stats.Entries),
+			log.Int64("size", stats.Size))
+	}
+	if a.DB != nil {
+		if err := a.DB.Close(); err != nil {
+			log.Error("failed to close cache database", log.ErrorField(err))
+		}
+	}
+	if a.SQL != nil {
+		if err := a.SQL.Close(); err != nil {
+			log.Error("failed to close cache database", log.ErrorField(err))
+		}
 	}
 }
@@ -0,0 +1,129 @@
+package export
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mpapenbr/irdata/irdata"
+)
+
+// Cars returns a row per car of the catalog (ordered by id)
+func Cars(c *irdata.Catalog) *Table {
+	type r = irdata.Car
+	return tableOf([]column[r]{
+		{"car_id", func(x *r) any { return x.CarID }},
+		{"car_name", func(x *r) any { return x.CarName }},
+		{"car_name_abbreviated", func(x *r) any { return x.CarNameAbbreviated }},
+		{"car_types", func(x *r) any {
+			types := make([]string, len(x.CarTypes))
+			for i := range x.CarTypes {
+				types[i] = x.CarTypes[i].CarType
+			}
+			return strings.Join(types, " ")
+		}},
+		{"hp", func(x *r) any { return x.HP }},
+		{"car_weight", func(x *r) any { return x.CarWeight }},
+		{"retired", func(x *r) any { return x.Retired }},
+		{"large_image_url", func(x *r) any {
+			if a, ok := c.CarAssets[x.CarID]; ok {
+				return a.LargeImageURL()
+			}
+			return ""
+		}},
+		{"logo_url", func(x *r) any {
+			if a, ok := c.CarAssets[x.CarID]; ok {
+				return a.LogoURL()
+			}
+			return ""
+		}},
+	}, SortedValues(c.Cars))
+}
+
+// CarClasses returns a row per car class of the catalog (ordered by id)
+func CarClasses(c *irdata.Catalog) *Table {
+	type r = irdata.CarClass
+	return tableOf([]column[r]{
+		{"car_class_id", func(x *r) any { return x.CarClassID }},
+		{"name", func(x *r) any { return x.Name }},
+		{"short_name", func(x *r) any { return x.ShortName }},
+		{"car_ids", func(x *r) any {
+			ids := make([]string, len(x.CarsInClass))
+			for i := range x.CarsInClass {
+				ids[i] = strconv.Itoa(x.CarsInClass[i].CarID)
+			}
+			return strings.Join(ids, " ")
+		}},
+	}, SortedValues(c.CarClasses))
+}
+
+// Tracks returns a row per track config of the catalog (ordered by id)
+func Tracks(c *irdata.Catalog) *Table {
+	type r = irdata.Track
+	return tableOf([]column[r]{
+		{"track_id", func(x *r) any { return x.TrackID }},
+		{"track_name", func(x *r) any { return x.TrackName }},
+		{"config_name", func(x *r) any { return x.ConfigName }},
+		{"category", func(x *r) any { return x.Category }},
+		{"location", func(x *r) any { return x.Location }},
+		{"track_config_length", func(x *r) any { return x.TrackConfigLength }},
+		{"corners_per_lap", func(x *r) any { return x.CornersPerLap }},
+		{"retired", func(x *r) any { return x.Retired }},
+		{"large_image_url", func(x *r) any {
+			if a, ok := c.TrackAssets[x.TrackID]; ok {
+				return a.LargeImageURL()
+			}
+			return ""
+		}},
+		{"track_map_url", func(x *r) any {
+			if a, ok := c.TrackAssets[x.TrackID]; ok {
+				return a.TrackMapLayerURLs()["active"]
+			}
+			return ""
+		}},
+	}, SortedValues(c.Tracks))
+}
+
+// CarAssets returns a row per car asset of the catalog (ordered by car id)
+func CarAssets(c *irdata.Catalog) *Table {
+	type r = irdata.CarAsset
+	return tableOf([]column[r]{
+		{"car_id", func(x *r) any { return x.CarID }},
+		{"large_image_url", func(x *r) any { return x.LargeImageURL() }},
+		{"small_image_url", func(x *r) any { return x.SmallImageURL() }},
+		{"logo_url", func(x *r) any { return x.LogoURL() }},
+		{"gallery_prefix", func(x *r) any { return x.GalleryPrefix }},
+		{"detail_copy", func(x *r) any { return x.DetailCopy }},
+	}, SortedValues(c.CarAssets))
+}
+
+// TrackAssets returns a row per track asset of the catalog (ordered by
+// track id). The track map layers are given as space separated
+// layer=url pairs.
+func TrackAssets(c *irdata.Catalog) *Table {
+	type r = irdata.TrackAsset
+	return tableOf([]column[r]{
+		{"track_id", func(x *r) any { return x.TrackID }},
+		{"large_image_url", func(x *r) any { return x.LargeImageURL() }},
+		{"small_image_url", func(x *r) any { return x.SmallImageURL() }},
+		{"logo_url", func(x *r) any { return x.LogoURL() }},
+		{"track_map_layers", func(x *r) any {
+			layers := x.TrackMapLayerURLs()
+			pairs := make([]string, 0, len(layers))
+			for _, name := range slices.Sorted(maps.Keys(layers)) {
+				pairs = append(pairs, name+"="+layers[name])
+			}
+			return strings.Join(pairs, " ")
+		}},
+	}, SortedValues(c.TrackAssets))
+}
+
+// SortedValues returns the values of m ordered by key
+func SortedValues[T any](m map[int]*T) []T {
+	ret := make([]T, 0, len(m))
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		ret = append(ret, *m[k])
+	}
+	return ret
+}
@@ -0,0 +1,196 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// Format is the output format of a Table
+	Format string
+
+	// Table is the flat representation of typed results.
+	// Cells are bool, int, float64, string or time.Time. Nullable columns
+	// use pointers to float64 or time.Time, nil pointers are missing values.
+	Table struct {
+		Columns []string
+		Types   []reflect.Type // type of the cells of the columns
+		Rows    [][]any
+	}
+
+	// column describes a column of a table of T
+	column[T any] struct {
+		name  string
+		value func(*T) any
+	}
+)
+
+const (
+	// FormatJSON is the JSON payload as delivered by the data API
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+	// FormatParquet is an Apache Parquet file (see WriteParquet)
+	FormatParquet Format = "parquet"
+)
+
+// ParseFormat returns the format for json, ndjson, csv or parquet
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatJSON, FormatNDJSON, FormatCSV, FormatParquet:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q (json, ndjson, csv, parquet)", s)
+	}
+}
+
+// Ext returns the file extension of f (including the dot)
+func (f Format) Ext() string {
+	return "." + string(f)
+}
+
+// Write writes t to w. FormatJSON writes the rows as array of objects.
+func Write(w io.Writer, f Format, t *Table) error {
+	switch f {
+	case FormatCSV:
+		return WriteCSV(w, t)
+	case FormatNDJSON:
+		return WriteNDJSON(w, t)
+	case FormatJSON:
+		return writeJSON(w, t)
+	case FormatParquet:
+		return WriteParquet(w, t)
+	default:
+		return fmt.Errorf("unsupported format %q", f)
+	}
+}
+
+// WriteCSV writes t as CSV with a header line. Missing values are written
+// as empty strings, times as RFC3339.
+func WriteCSV(w io.Writer, t *Table) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Columns); err != nil {
+		return err
+	}
+	record := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for j := range row {
+			record[j] = cellString(row[j])
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteNDJSON writes one JSON object per row. The keys are ordered
+// like the columns.
+func WriteNDJSON(w io.Writer, t *Table) error {
+	bw := bufio.NewWriter(w)
+	var buf []byte
+	for _, row := range t.Rows {
+		var err error
+		if buf, err = appendObject(buf[:0], t.Columns, row); err != nil {
+			return err
+		}
+		if _, err = bw.Write(append(buf, '\n')); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func writeJSON(w io.Writer, t *Table) error {
+	buf := []byte{'['}
+	for i, row := range t.Rows {
+		if i > 0 {
+			buf = append(buf, ",\n"...)
+		}
+		var err error
+		if buf, err = appendObject(buf, t.Columns, row); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(append(buf, "]\n"...))
+	return err
+}
+
+// appendObject appends row as JSON object with the columns as keys to buf
+func appendObject(buf []byte, columns []string, row []any) ([]byte, error) {
+	buf = append(buf, '{')
+	for j := range row {
+		if j > 0 {
+			buf = append(buf, ',')
+		}
+		key, err := json.Marshal(columns[j])
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(row[j])
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(append(buf, key...), ':'), value...)
+	}
+	return append(buf, '}'), nil
+}
+
+func cellString(v any) string {
+	switch x := v.(type) {
+	case *float64:
+		if x == nil {
+			return ""
+		}
+		return cellString(*x)
+	case *time.Time:
+		if x == nil {
+			return ""
+		}
+		return cellString(*x)
+	case string:
+		return x
+	case int:
+		return strconv.Itoa(x)
+	case bool:
+		return strconv.FormatBool(x)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case time.Time:
+		return x.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// tableOf builds the table of rows with the given columns.
+// The column types are taken from the values of a zero T, so the columns
+// have to return typed values for it (see lapSeconds).
+func tableOf[T any](columns []column[T], rows []T) *Table {
+	t := &Table{
+		Columns: make([]string, len(columns)),
+		Types:   make([]reflect.Type, len(columns)),
+		Rows:    make([][]any, 0, len(rows)),
+	}
+	var zero T
+	for j := range columns {
+		t.Columns[j] = columns[j].name
+		t.Types[j] = reflect.TypeOf(columns[j].value(&zero))
+	}
+	for i := range rows {
+		row := make([]any, len(columns))
+		for j := range columns {
+			row[j] = columns[j].value(&rows[i])
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Format
+		wantExt string
+		wantErr bool
+	}{
+		{"json", FormatJSON, ".json", false},
+		{"ndjson", FormatNDJSON, ".ndjson", false},
+		{"CSV", FormatCSV, ".csv", false},
+		{"Parquet", FormatParquet, ".parquet", false},
+		{"xlsx", "", "", true},
+		{"", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseFormat(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if !tt.wantErr && got.Ext() != tt.wantExt {
+				t.Errorf("Ext() = %q, want %q", got.Ext(), tt.wantExt)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	lap := 83.456
+	start := time.Date(2026, 3, 17, 18, 45, 0, 0, time.UTC)
+	table := tableOf([]column[int]{
+		{"id", func(x *int) any { return *x }},
+		{"name", func(x *int) any {
+			if *x == 1 {
+				return `Max "the fast" Driver`
+			}
+			return "Driver, Second"
+		}},
+		{"lap_time", func(x *int) any {
+			if *x == 1 {
+				return &lap
+			}
+			return (*float64)(nil)
+		}},
+		{"start_time", func(*int) any { return &start }},
+	}, []int{1, 2})
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatCSV, "id,name,lap_time,start_time\n" +
+			"1,\"Max \"\"the fast\"\" Driver\",83.456,2026-03-17T18:45:00Z\n" +
+			"2,\"Driver, Second\",,2026-03-17T18:45:00Z\n"},
+		{FormatNDJSON, "" +
+			`{"id":1,"name":"Max \"the fast\" Driver","lap_time":83.456,` +
+			`"start_time":"2026-03-17T18:45:00Z"}` + "\n" +
+			`{"id":2,"name":"Driver, Second","lap_time":null,` +
+			`"start_time":"2026-03-17T18:45:00Z"}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tt.format, table); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
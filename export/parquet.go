@@ -0,0 +1,127 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Parquet files contain one column per table column with these types:
+//
+//	int        INT64 (INT(64, signed))
+//	float64    DOUBLE
+//	bool       BOOLEAN
+//	string     BYTE_ARRAY (STRING)
+//	time.Time  INT64 (TIMESTAMP(MILLIS, UTC))
+//
+// Nullable columns (lap times, intervals, times) are OPTIONAL, all others
+// REQUIRED. Lap times and intervals are given in seconds. The columns are
+// stored in alphabetical order, the pages are compressed with zstd.
+
+var (
+	typeInt     = reflect.TypeFor[int]()
+	typeFloat   = reflect.TypeFor[float64]()
+	typeBool    = reflect.TypeFor[bool]()
+	typeString  = reflect.TypeFor[string]()
+	typeTime    = reflect.TypeFor[time.Time]()
+	parquetType = map[reflect.Type]func() parquet.Node{
+		typeInt:    func() parquet.Node { return parquet.Int(64) },
+		typeFloat:  func() parquet.Node { return parquet.Leaf(parquet.DoubleType) },
+		typeBool:   func() parquet.Node { return parquet.Leaf(parquet.BooleanType) },
+		typeString: parquet.String,
+		typeTime:   func() parquet.Node { return parquet.Timestamp(parquet.Millisecond) },
+	}
+)
+
+// PartitionDir returns the directory of the data of a race week below base.
+// The layout season_id=<id>/race_week_num=<week> is understood as
+// partitioning by most Parquet readers.
+func PartitionDir(base string, seasonID, raceWeekNum int) string {
+	return filepath.Join(base,
+		fmt.Sprintf("season_id=%d", seasonID),
+		fmt.Sprintf("race_week_num=%d", raceWeekNum))
+}
+
+// WriteParquet writes t as Parquet file to w.
+func WriteParquet(w io.Writer, t *Table) error {
+	group := parquet.Group{}
+	for j, name := range t.Columns {
+		node, err := parquetNode(t.Types[j])
+		if err != nil {
+			return fmt.Errorf("column %s: %w", name, err)
+		}
+		group[name] = node
+	}
+	schema := parquet.NewSchema("row", group)
+	// the leaf columns of the schema are sorted by name
+	leaves := make([]int, len(t.Columns))
+	for j, name := range t.Columns {
+		leaf, ok := schema.Lookup(name)
+		if !ok {
+			return fmt.Errorf("column %s: not in schema", name)
+		}
+		leaves[j] = leaf.ColumnIndex
+	}
+	rows := make([]parquet.Row, len(t.Rows))
+	for i, cells := range t.Rows {
+		row := make(parquet.Row, len(cells))
+		for j, v := range cells {
+			value, def := parquetValue(v)
+			row[leaves[j]] = value.Level(0, def, leaves[j])
+		}
+		rows[i] = row
+	}
+	pw := parquet.NewWriter(w, schema, parquet.Compression(&parquet.Zstd))
+	if _, err := pw.WriteRows(rows); err != nil {
+		return err
+	}
+	return pw.Close()
+}
+
+func parquetNode(t reflect.Type) (parquet.Node, error) {
+	optional := t != nil && t.Kind() == reflect.Pointer
+	if optional {
+		t = t.Elem()
+	}
+	node, ok := parquetType[t]
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %v", t)
+	}
+	if optional {
+		return parquet.Optional(node()), nil
+	}
+	return node(), nil
+}
+
+// parquetValue returns the parquet value of the cell v together with its
+// definition level (1 for values of optional columns)
+func parquetValue(v any) (parquet.Value, int) {
+	switch x := v.(type) {
+	case *float64:
+		if x == nil {
+			return parquet.NullValue(), 0
+		}
+		return parquet.DoubleValue(*x), 1
+	case *time.Time:
+		if x == nil {
+			return parquet.NullValue(), 0
+		}
+		return parquet.Int64Value(x.UnixMilli()), 1
+	case int:
+		return parquet.Int64Value(int64(x)), 0
+	case float64:
+		return parquet.DoubleValue(x), 0
+	case bool:
+		return parquet.BooleanValue(x), 0
+	case string:
+		return parquet.ByteArrayValue([]byte(x)), 0
+	case time.Time:
+		return parquet.Int64Value(x.UnixMilli()), 0
+	default:
+		return parquet.NullValue(), 0
+	}
+}
@@ -0,0 +1,137 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestWriteParquetColumnOrder(t *testing.T) {
+	lap := 83.456
+	start := time.Date(2026, 3, 17, 18, 45, 0, 0, time.UTC)
+	// cells by column name, lap_time is missing in the second row
+	cells := []map[string]any{
+		{
+			"subsession_id": 81000001, "lap_time": &lap, "display_name": "Driver A",
+			"ai": false, "start_time": start,
+		},
+		{
+			"subsession_id": 81000001, "lap_time": (*float64)(nil),
+			"display_name": "Driver B", "ai": true, "start_time": start,
+		},
+	}
+	// the cells of the rows as read back by column name
+	want := []map[string]any{
+		{
+			"subsession_id": int64(81000001), "lap_time": 83.456,
+			"display_name": "Driver A", "ai": false, "start_time": start.UnixMilli(),
+		},
+		{
+			"subsession_id": int64(81000001), "lap_time": nil,
+			"display_name": "Driver B", "ai": true, "start_time": start.UnixMilli(),
+		},
+	}
+	sorted := []string{"ai", "display_name", "lap_time", "start_time", "subsession_id"}
+	tests := []struct {
+		name    string
+		columns []string
+	}{
+		{"table order", []string{
+			"subsession_id", "display_name", "lap_time", "start_time", "ai",
+		}},
+		{"sorted", sorted},
+		{"reversed", []string{
+			"subsession_id", "start_time", "lap_time", "display_name", "ai",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := &Table{Columns: tt.columns}
+			for _, name := range tt.columns {
+				table.Types = append(table.Types, reflect.TypeOf(cells[0][name]))
+			}
+			for _, c := range cells {
+				row := make([]any, len(tt.columns))
+				for j, name := range tt.columns {
+					row[j] = c[name]
+				}
+				table.Rows = append(table.Rows, row)
+			}
+			var buf bytes.Buffer
+			if err := WriteParquet(&buf, table); err != nil {
+				t.Fatalf("WriteParquet() error = %v", err)
+			}
+			names, rows := readParquet(t, buf.Bytes())
+			if !slices.Equal(names, sorted) {
+				t.Errorf("columns = %v, want %v", names, sorted)
+			}
+			if !reflect.DeepEqual(rows, want) {
+				t.Errorf("rows = %v, want %v", rows, want)
+			}
+		})
+	}
+}
+
+func TestWriteParquetUnsupportedType(t *testing.T) {
+	table := &Table{
+		Columns: []string{"ids"},
+		Types:   []reflect.Type{reflect.TypeFor[[]int]()},
+		Rows:    [][]any{{[]int{1}}},
+	}
+	if err := WriteParquet(io.Discard, table); err == nil {
+		t.Error("WriteParquet() error = nil, want error for []int column")
+	}
+}
+
+// readParquet returns the column names and the rows (by column name) of
+// the Parquet file data
+func readParquet(t *testing.T, data []byte) ([]string, []map[string]any) {
+	t.Helper()
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	var names []string
+	for _, field := range f.Schema().Fields() {
+		names = append(names, field.Name())
+	}
+	var ret []map[string]any
+	for _, rg := range f.RowGroups() {
+		rows := rg.Rows()
+		buf := make([]parquet.Row, rg.NumRows())
+		n, err := rows.ReadRows(buf)
+		if err != nil && !errors.Is(err, io.EOF) {
+			t.Fatalf("ReadRows() error = %v", err)
+		}
+		rows.Close()
+		for _, row := range buf[:n] {
+			cells := map[string]any{}
+			for _, v := range row {
+				cells[names[v.Column()]] = parquetCell(v)
+			}
+			ret = append(ret, cells)
+		}
+	}
+	return names, ret
+}
+
+func parquetCell(v parquet.Value) any {
+	switch v.Kind() {
+	case parquet.Boolean:
+		return v.Boolean()
+	case parquet.Int64:
+		return v.Int64()
+	case parquet.Double:
+		return v.Double()
+	case parquet.ByteArray:
+		return string(v.ByteArray())
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,311 @@
+package export
+
+import (
+	"strings"
+	"time"
+
+	"github.com/mpapenbr/irdata/irdata"
+)
+
+// The tables below have a fixed column set. Lap times and intervals
+// are given in seconds, missing values (no lap time, lapped cars) as nil.
+
+type (
+	// subsessionRow is the result of a driver in a sim session
+	subsessionRow struct {
+		simsessionNumber   int
+		simsessionTypeName string
+		teamID             int
+		r                  irdata.DriverResult
+	}
+)
+
+// Seasons returns a row per season
+func Seasons(seasons []irdata.Season) *Table {
+	return tableOf([]column[irdata.Season]{
+		{"season_id", func(s *irdata.Season) any { return s.SeasonID }},
+		{"series_id", func(s *irdata.Season) any { return s.SeriesID }},
+		{"season_year", func(s *irdata.Season) any { return s.SeasonYear }},
+		{"season_quarter", func(s *irdata.Season) any { return s.SeasonQuarter }},
+		{"season_name", func(s *irdata.Season) any { return s.SeasonName }},
+		{"season_short_name", func(s *irdata.Season) any { return s.SeasonShortName }},
+		{"series_name", func(s *irdata.Season) any { return s.SeriesName }},
+		{"license_group", func(s *irdata.Season) any { return s.LicenseGroup }},
+		{"official", func(s *irdata.Season) any { return s.Official }},
+		{"active", func(s *irdata.Season) any { return s.Active }},
+		{"categories", func(s *irdata.Season) any {
+			names := make([]string, 0, len(s.TrackTypes))
+			for _, c := range s.Categories() {
+				names = append(names, c.String())
+			}
+			return strings.Join(names, " ")
+		}},
+	}, seasons)
+}
+
+// PastSeasons returns a row per past season of a series
+func PastSeasons(seasons []irdata.PastSeason) *Table {
+	type r = irdata.PastSeason
+	return tableOf([]column[r]{
+		{"season_id", func(x *r) any { return x.SeasonID }},
+		{"series_id", func(x *r) any { return x.SeriesID }},
+		{"season_year", func(x *r) any { return x.SeasonYear }},
+		{"season_quarter", func(x *r) any { return x.SeasonQuarter }},
+		{"season_name", func(x *r) any { return x.SeasonName }},
+		{"season_short_name", func(x *r) any { return x.SeasonShortName }},
+		{"license_group", func(x *r) any { return x.LicenseGroup }},
+		{"official", func(x *r) any { return x.Official }},
+		{"active", func(x *r) any { return x.Active }},
+		{"driver_changes", func(x *r) any { return x.DriverChanges }},
+		{"fixed_setup", func(x *r) any { return x.FixedSetup }},
+		{"race_weeks", func(x *r) any { return len(x.RaceWeeks) }},
+	}, seasons)
+}
+
+// Schedules returns a row per race week
+func Schedules(schedules []irdata.Schedule) *Table {
+	return tableOf([]column[irdata.Schedule]{
+		{"season_id", func(s *irdata.Schedule) any { return s.SeasonID }},
+		{"race_week_num", func(s *irdata.Schedule) any { return s.RaceWeekNum }},
+		{"qual_attached", func(s *irdata.Schedule) any { return s.QualAttached }},
+		{"track_id", func(s *irdata.Schedule) any { return s.Track.TrackID }},
+		{"track_name", func(s *irdata.Schedule) any { return s.Track.TrackName }},
+		{"config_name", func(s *irdata.Schedule) any { return s.Track.ConfigName }},
+	}, schedules)
+}
+
+// SeasonResults returns a row per session of a race week
+func SeasonResults(data *irdata.SeasonResultsData) *Table {
+	type r = irdata.SeasonResult
+	return tableOf([]column[r]{
+		{"season_id", func(*r) any { return data.SeasonID }},
+		{"race_week_num", func(x *r) any { return x.RaceWeekNum }},
+		{"event_type", func(x *r) any { return int(x.EventType) }},
+		{"event_type_name", func(x *r) any { return x.EventTypeName }},
+		{"start_time", func(x *r) any { return timeValue(x.StartTime) }},
+		{"session_id", func(x *r) any { return x.SessionID }},
+		{"subsession_id", func(x *r) any { return x.SubsessionID }},
+		{"official_session", func(x *r) any { return x.OfficialSession }},
+		{"event_strength_of_field", func(x *r) any { return x.EventStrengthOfField }},
+		{"event_best_lap_time", func(x *r) any { return lapSeconds(x.EventBestLapTime) }},
+		{"num_cautions", func(x *r) any { return x.NumCautions }},
+		{"num_caution_laps", func(x *r) any { return x.NumCautionLaps }},
+		{"num_lead_changes", func(x *r) any { return x.NumLeadChanges }},
+		{"driver_changes", func(x *r) any { return x.DriverChanges }},
+		{"winner_group_id", func(x *r) any { return x.WinnerGroupID }},
+		{"winner_name", func(x *r) any { return x.WinnerName }},
+		{"winner_ai", func(x *r) any { return x.WinnerAI }},
+		{"track_id", func(x *r) any { return x.Track.TrackID }},
+		{"track_name", func(x *r) any { return x.Track.TrackName }},
+		{"config_name", func(x *r) any { return x.Track.ConfigName }},
+		{"num_drivers", func(x *r) any { return x.NumDrivers }},
+	}, data.ResultsList)
+}
+
+// SubsessionResults returns a row per driver and sim session of s.
+// For team events the rows contain the results of the team drivers.
+//
+//nolint:funlen // column list
+func SubsessionResults(s *irdata.SubsessionResult) *Table {
+	type r = subsessionRow
+	return tableOf([]column[r]{
+		{"subsession_id", func(*r) any { return s.SubsessionID }},
+		{"session_id", func(*r) any { return s.SessionID }},
+		{"season_id", func(*r) any { return s.SeasonID }},
+		{"series_id", func(*r) any { return s.SeriesID }},
+		{"season_year", func(*r) any { return s.SeasonYear }},
+		{"season_quarter", func(*r) any { return s.SeasonQuarter }},
+		{"race_week_num", func(*r) any { return s.RaceWeekNum }},
+		{"start_time", func(*r) any { return timeValue(s.StartTime) }},
+		{"track_id", func(*r) any { return s.Track.TrackID }},
+		{"simsession_number", func(x *r) any { return x.simsessionNumber }},
+		{"simsession_type_name", func(x *r) any { return x.simsessionTypeName }},
+		{"team_id", func(x *r) any { return x.teamID }},
+		{"cust_id", func(x *r) any { return x.r.CustID }},
+		{"display_name", func(x *r) any { return x.r.DisplayName }},
+		{"car_id", func(x *r) any { return x.r.CarID }},
+		{"car_name", func(x *r) any { return x.r.CarName }},
+		{"car_class_id", func(x *r) any { return x.r.CarClassID }},
+		{"car_class_name", func(x *r) any { return x.r.CarClassName }},
+		{"starting_position", func(x *r) any { return x.r.StartingPosition }},
+		{"starting_position_in_class", func(x *r) any {
+			return x.r.StartingPositionInClass
+		}},
+		{"finish_position", func(x *r) any { return x.r.FinishPosition }},
+		{"finish_position_in_class", func(x *r) any { return x.r.FinishPositionInClass }},
+		{"laps_complete", func(x *r) any { return x.r.LapsComplete }},
+		{"laps_lead", func(x *r) any { return x.r.LapsLead }},
+		{"incidents", func(x *r) any { return x.r.Incidents }},
+		{"interval", func(x *r) any { return intervalSeconds(x.r.Interval) }},
+		{"class_interval", func(x *r) any { return intervalSeconds(x.r.ClassInterval) }},
+		{"average_lap", func(x *r) any { return lapSeconds(x.r.AverageLap) }},
+		{"best_lap_time", func(x *r) any { return lapSeconds(x.r.BestLapTime) }},
+		{"best_lap_num", func(x *r) any { return x.r.BestLapNum }},
+		{"qual_lap_time", func(x *r) any { return lapSeconds(x.r.QualLapTime) }},
+		{"reason_out", func(x *r) any { return x.r.ReasonOut }},
+		{"champ_points", func(x *r) any { return x.r.ChampPoints }},
+		{"oldi_rating", func(x *r) any { return x.r.OldiRating }},
+		{"newi_rating", func(x *r) any { return x.r.NewiRating }},
+		{"old_license_level", func(x *r) any { return x.r.OldLicenseLevel }},
+		{"new_license_level", func(x *r) any { return x.r.NewLicenseLevel }},
+		{"old_sub_level", func(x *r) any { return x.r.OldSubLevel }},
+		{"new_sub_level", func(x *r) any { return x.r.NewSubLevel }},
+		{"division", func(x *r) any { return x.r.Division }},
+		{"ai", func(x *r) any { return x.r.AI }},
+	}, subsessionRows(s))
+}
+
+// Laps returns a row per lap of the sim session described by info
+// (see LapData and LapChartData)
+func Laps(info *irdata.LapSessionInfo, laps []irdata.Lap) *Table {
+	type r = irdata.Lap
+	return tableOf([]column[r]{
+		{"subsession_id", func(*r) any { return info.SubsessionID }},
+		{"simsession_number", func(*r) any { return info.SimsessionNumber }},
+		{"group_id", func(x *r) any { return x.GroupID }},
+		{"cust_id", func(x *r) any { return x.CustID }},
+		{"display_name", func(x *r) any { return x.DisplayName }},
+		{"car_number", func(x *r) any { return x.CarNumber }},
+		{"lap_number", func(x *r) any { return x.LapNumber }},
+		{"lap_position", func(x *r) any { return x.LapPosition }},
+		{"lap_time", func(x *r) any { return lapSeconds(x.LapTime) }},
+		{"session_time", func(x *r) any { return intervalSeconds(x.SessionTime) }},
+		{"interval", func(x *r) any { return intervalSeconds(x.Interval) }},
+		{"flags", func(x *r) any { return x.Flags }},
+		{"incident", func(x *r) any { return x.Incident }},
+		{"lap_events", func(x *r) any { return strings.Join(x.LapEvents, " ") }},
+		{"personal_best_lap", func(x *r) any { return x.PersonalBestLap }},
+		{"team_fastest_lap", func(x *r) any { return x.TeamFastestLap }},
+		{"fastest_lap", func(x *r) any { return x.FastestLap }},
+		{"ai", func(x *r) any { return x.AI }},
+	}, laps)
+}
+
+// SearchResults returns a row per search result
+//
+//nolint:funlen // column list
+func SearchResults(results []irdata.SearchResult) *Table {
+	type r = irdata.SearchResult
+	return tableOf([]column[r]{
+		{"session_id", func(x *r) any { return x.SessionID }},
+		{"subsession_id", func(x *r) any { return x.SubsessionID }},
+		{"start_time", func(x *r) any { return timeValue(x.StartTime) }},
+		{"end_time", func(x *r) any { return timeValue(x.EndTime) }},
+		{"license_category_id", func(x *r) any { return x.LicenseCategoryID }},
+		{"license_category", func(x *r) any { return x.LicenseCategory }},
+		{"num_drivers", func(x *r) any { return x.NumDrivers }},
+		{"official_session", func(x *r) any { return x.OfficialSession }},
+		{"event_type", func(x *r) any { return int(x.EventType) }},
+		{"event_type_name", func(x *r) any { return x.EventTypeName }},
+		{"season_id", func(x *r) any { return x.SeasonID }},
+		{"season_year", func(x *r) any { return x.SeasonYear }},
+		{"season_quarter", func(x *r) any { return x.SeasonQuarter }},
+		{"series_id", func(x *r) any { return x.SeriesID }},
+		{"series_name", func(x *r) any { return x.SeriesName }},
+		{"race_week_num", func(x *r) any { return x.RaceWeekNum }},
+		{"session_name", func(x *r) any { return x.SessionName }},
+		{"league_id", func(x *r) any { return x.LeagueID }},
+		{"league_season_id", func(x *r) any { return x.LeagueSeasonID }},
+		{"host_cust_id", func(x *r) any { return x.Host.CustID }},
+		{"track_id", func(x *r) any { return x.Track.TrackID }},
+		{"track_name", func(x *r) any { return x.Track.TrackName }},
+		{"config_name", func(x *r) any { return x.Track.ConfigName }},
+		{"event_strength_of_field", func(x *r) any { return x.EventStrengthOfField }},
+		{"event_best_lap_time", func(x *r) any { return lapSeconds(x.EventBestLapTime) }},
+		{"winner_name", func(x *r) any { return x.WinnerName }},
+		{"cust_id", func(x *r) any { return x.CustID }},
+		{"team_id", func(x *r) any { return x.TeamID }},
+		{"display_name", func(x *r) any { return x.DisplayName }},
+		{"car_id", func(x *r) any { return x.CarID }},
+		{"car_name", func(x *r) any { return x.CarName }},
+		{"car_class_id", func(x *r) any { return x.CarClassID }},
+		{"car_class_name", func(x *r) any { return x.CarClassName }},
+		{"starting_position", func(x *r) any { return x.StartingPosition }},
+		{"finish_position", func(x *r) any { return x.FinishPosition }},
+		{"finish_position_in_class", func(x *r) any { return x.FinishPositionInClass }},
+	}, results)
+}
+
+// LeagueDriverStandings returns a row per driver of the league standings
+func LeagueDriverStandings(resp *irdata.LeagueSeasonStandingsResponse) *Table {
+	type r = irdata.LeagueDriverStanding
+	return tableOf([]column[r]{
+		{"league_id", func(*r) any { return resp.LeagueID }},
+		{"season_id", func(*r) any { return resp.SeasonID }},
+		{"position", func(x *r) any { return x.Position }},
+		{"cust_id", func(x *r) any { return x.Driver.CustID }},
+		{"display_name", func(x *r) any { return x.Driver.DisplayName }},
+		{"car_number", func(x *r) any { return x.CarNumber }},
+		{"wins", func(x *r) any { return x.Wins }},
+		{"average_start", func(x *r) any { return x.AverageStart }},
+		{"average_finish", func(x *r) any { return x.AverageFinish }},
+		{"base_points", func(x *r) any { return x.BasePoints }},
+		{"total_points", func(x *r) any { return x.TotalPoints }},
+	}, resp.Standings.DriverStandings)
+}
+
+// LeagueTeamStandings returns a row per team of the league standings
+func LeagueTeamStandings(resp *irdata.LeagueSeasonStandingsResponse) *Table {
+	type r = irdata.LeagueTeamStanding
+	return tableOf([]column[r]{
+		{"league_id", func(*r) any { return resp.LeagueID }},
+		{"season_id", func(*r) any { return resp.SeasonID }},
+		{"position", func(x *r) any { return x.Position }},
+		{"team_id", func(x *r) any { return x.TeamID }},
+		{"team_name", func(x *r) any { return x.TeamName }},
+		{"wins", func(x *r) any { return x.Wins }},
+		{"average_start", func(x *r) any { return x.AverageStart }},
+		{"average_finish", func(x *r) any { return x.AverageFinish }},
+		{"base_points", func(x *r) any { return x.BasePoints }},
+		{"total_points", func(x *r) any { return x.TotalPoints }},
+	}, resp.Standings.TeamStandings)
+}
+
+func subsessionRows(s *irdata.SubsessionResult) []subsessionRow {
+	var ret []subsessionRow
+	for i := range s.SessionResults {
+		sim := &s.SessionResults[i]
+		row := subsessionRow{
+			simsessionNumber:   sim.SimsessionNumber,
+			simsessionTypeName: sim.SimsessionTypeName,
+		}
+		for j := range sim.Results {
+			res := &sim.Results[j]
+			row.teamID = res.TeamID
+			if len(res.DriverResults) == 0 {
+				row.r = *res
+				ret = append(ret, row)
+				continue
+			}
+			for k := range res.DriverResults {
+				row.r = res.DriverResults[k]
+				ret = append(ret, row)
+			}
+		}
+	}
+	return ret
+}
+
+// lapSeconds returns nil for invalid lap times. The result is a *float64
+// even then, so tableOf can determine the column type.
+func lapSeconds(t irdata.LapTime) *float64 {
+	if !t.Valid() {
+		return nil
+	}
+	return new(t.Seconds())
+}
+
+// intervalSeconds returns nil for negative intervals (lapped cars)
+func intervalSeconds(t irdata.LapTime) *float64 {
+	if t < 0 {
+		return nil
+	}
+	return new(t.Seconds())
+}
+
+func timeValue(t irdata.IRTime) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return new(t.UTC())
+}
@@ -0,0 +1,88 @@
+package irdata
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mpapenbr/irdata/cache"
+)
+
+type (
+	// CallOption controls the cache usage of a single call.
+	CallOption  func(*callOptions)
+	callOptions struct {
+		noCache   bool
+		refresh   bool
+		cacheOnly bool
+		maxAge    time.Duration
+	}
+)
+
+// ErrNotCached is returned by calls restricted to the cache (see CacheOnly
+// and WithOffline) if the data is not in the cache.
+var ErrNotCached = errors.New("not in cache")
+
+// NoCache bypasses the cache: the data is neither read from
+// nor written to the cache.
+func NoCache() CallOption {
+	return func(o *callOptions) {
+		o.noCache = true
+	}
+}
+
+// ForceRefresh fetches the data even if it is cached and overwrites
+// the cache entry.
+func ForceRefresh() CallOption {
+	return func(o *callOptions) {
+		o.refresh = true
+	}
+}
+
+// CacheOnly serves the data from the cache only (including expired entries).
+// If the data is not cached, the call fails with ErrNotCached.
+// Note: chunk files of chunked results are not cached and still downloaded.
+func CacheOnly() CallOption {
+	return func(o *callOptions) {
+		o.cacheOnly = true
+	}
+}
+
+// MaxAge fetches the data if the cache entry is older than arg.
+// Entries of unknown age (caches not implementing cache.EntryReader)
+// are fetched as well.
+func MaxAge(arg time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.maxAge = arg
+	}
+}
+
+// WithCallOptions sets the default options of all calls.
+// Options passed to a call are applied after the defaults.
+func WithCallOptions(opts ...CallOption) Option {
+	return func(c *config) {
+		c.callOpts = opts
+	}
+}
+
+// resolveCallOptions returns the effective options of a call
+func (i *IrData) resolveCallOptions(opts []CallOption) *callOptions {
+	ret := &callOptions{cacheOnly: i.cfg.offline}
+	for _, opt := range i.cfg.callOpts {
+		opt(ret)
+	}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	return ret
+}
+
+// usable reports whether entry may be used without fetching the data
+func (o *callOptions) usable(entry *cache.Entry, now time.Time) bool {
+	if entry.Expired(now) {
+		return false
+	}
+	if o.maxAge == 0 {
+		return true
+	}
+	return !entry.FetchedAt.IsZero() && now.Sub(entry.FetchedAt) <= o.maxAge
+}
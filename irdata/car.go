@@ -0,0 +1,80 @@
+package irdata
+
+import (
+	"context"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	Car struct {
+		CarID              int       `json:"car_id,omitempty"`
+		CarName            string    `json:"car_name,omitempty"`
+		CarNameAbbreviated string    `json:"car_name_abbreviated,omitempty"`
+		CarDirpath         string    `json:"car_dirpath,omitempty"`
+		CarTypes           []CarType `json:"car_types,omitempty"`
+		HP                 int       `json:"hp,omitempty"`
+		CarWeight          int       `json:"car_weight,omitempty"`
+		FreeWithSubscr     bool      `json:"free_with_subscription,omitempty"`
+		PackageID          int       `json:"package_id,omitempty"`
+		Retired            bool      `json:"retired,omitempty"`
+		Sku                int       `json:"sku,omitempty"`
+	}
+	CarType struct {
+		CarType string `json:"car_type,omitempty"`
+	}
+	CarAsset struct {
+		CarID         int    `json:"car_id,omitempty"`
+		Folder        string `json:"folder,omitempty"`
+		LargeImage    string `json:"large_image,omitempty"`
+		SmallImage    string `json:"small_image,omitempty"`
+		Logo          string `json:"logo,omitempty"`
+		GalleryPrefix string `json:"gallery_prefix,omitempty"`
+		DetailCopy    string `json:"detail_copy,omitempty"`
+	}
+
+	CarClass struct {
+		CarClassID  int          `json:"car_class_id,omitempty"`
+		Name        string       `json:"name,omitempty"`
+		ShortName   string       `json:"short_name,omitempty"`
+		CarsInClass []CarInClass `json:"cars_in_class,omitempty"`
+	}
+	CarInClass struct {
+		CarID      int    `json:"car_id,omitempty"`
+		CarDirpath string `json:"car_dirpath,omitempty"`
+		Retired    bool   `json:"retired,omitempty"`
+	}
+)
+
+// LargeImageURL returns the absolute URL of the large car image.
+func (a *CarAsset) LargeImageURL() string {
+	return assetURL(a.Folder, a.LargeImage)
+}
+
+// SmallImageURL returns the absolute URL of the small car image.
+func (a *CarAsset) SmallImageURL() string {
+	return assetURL(a.Folder, a.SmallImage)
+}
+
+// LogoURL returns the absolute URL of the manufacturer logo.
+func (a *CarAsset) LogoURL() string {
+	return assetURL(a.Logo)
+}
+
+func (i *IrData) Cars(ctx context.Context, opts ...CallOption) ([]Car, error) {
+	return GetAs[[]Car](ctx, i, "/data/car/get", nil, opts...)
+}
+
+// CarAssets returns the car assets indexed by car id.
+func (i *IrData) CarAssets(
+	ctx context.Context,
+	opts ...CallOption,
+) (map[int]CarAsset, error) {
+	return GetAs[map[int]CarAsset](ctx, i, "/data/car/assets", nil, opts...)
+}
+
+func (i *IrData) CarClasses(
+	ctx context.Context,
+	opts ...CallOption,
+) ([]CarClass, error) {
+	return GetAs[[]CarClass](ctx, i, "/data/carclass/get", nil, opts...)
+}
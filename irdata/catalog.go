@@ -0,0 +1,200 @@
+package irdata
+
+import (
+	"context"
+	"strings"
+)
+
+type (
+	// Catalog indexes cars, car classes and tracks by their id.
+	// It is used to enrich typed results that only carry ids with names.
+	Catalog struct {
+		Cars        map[int]*Car
+		CarClasses  map[int]*CarClass
+		Tracks      map[int]*Track
+		CarAssets   map[int]*CarAsset
+		TrackAssets map[int]*TrackAsset
+	}
+)
+
+// imagesBaseURL is the host serving the images referenced by the asset endpoints
+const imagesBaseURL = "https://images-static.iracing.com"
+
+// assetURL resolves the (relative) asset path parts against the images host.
+// Returns an empty string if the last part is empty.
+func assetURL(parts ...string) string {
+	if len(parts) == 0 || parts[len(parts)-1] == "" {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(imagesBaseURL)
+	for _, p := range parts {
+		p = strings.Trim(p, "/")
+		if p == "" {
+			continue
+		}
+		sb.WriteString("/")
+		sb.WriteString(p)
+	}
+	return sb.String()
+}
+
+// NewCatalog creates a catalog from the given cars, car classes and tracks.
+func NewCatalog(cars []Car, carClasses []CarClass, tracks []Track) *Catalog {
+	c := &Catalog{
+		Cars:        make(map[int]*Car, len(cars)),
+		CarClasses:  make(map[int]*CarClass, len(carClasses)),
+		Tracks:      make(map[int]*Track, len(tracks)),
+		CarAssets:   map[int]*CarAsset{},
+		TrackAssets: map[int]*TrackAsset{},
+	}
+	for i := range cars {
+		c.Cars[cars[i].CarID] = &cars[i]
+	}
+	for i := range carClasses {
+		c.CarClasses[carClasses[i].CarClassID] = &carClasses[i]
+	}
+	for i := range tracks {
+		c.Tracks[tracks[i].TrackID] = &tracks[i]
+	}
+	return c
+}
+
+// LoadCatalog fetches cars, car classes, tracks and their assets
+// and returns them as catalog.
+func (i *IrData) LoadCatalog(
+	ctx context.Context,
+	opts ...CallOption,
+) (*Catalog, error) {
+	cars, err := i.Cars(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	carClasses, err := i.CarClasses(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	tracks, err := i.Tracks(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c := NewCatalog(cars, carClasses, tracks)
+
+	carAssets, err := i.CarAssets(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for id := range carAssets {
+		a := carAssets[id]
+		c.CarAssets[id] = &a
+	}
+	trackAssets, err := i.TrackAssets(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for id := range trackAssets {
+		a := trackAssets[id]
+		c.TrackAssets[id] = &a
+	}
+	return c, nil
+}
+
+// CarName returns the name of the car or an empty string if unknown.
+func (c *Catalog) CarName(carID int) string {
+	if car, ok := c.Cars[carID]; ok {
+		return car.CarName
+	}
+	return ""
+}
+
+// CarClassName returns the name of the car class or an empty string if unknown.
+func (c *Catalog) CarClassName(carClassID int) string {
+	if cc, ok := c.CarClasses[carClassID]; ok {
+		return cc.Name
+	}
+	return ""
+}
+
+// TrackName returns the full name (including config) of the track
+// or an empty string if unknown.
+func (c *Catalog) TrackName(trackID int) string {
+	if t, ok := c.Tracks[trackID]; ok {
+		return t.FullName()
+	}
+	return ""
+}
+
+// EnrichTrack fills missing names of t from the catalog.
+func (c *Catalog) EnrichTrack(t *TrackRef) {
+	track, ok := c.Tracks[t.TrackID]
+	if !ok {
+		return
+	}
+	if t.TrackName == "" {
+		t.TrackName = track.TrackName
+	}
+	if t.ConfigName == "" {
+		t.ConfigName = track.ConfigName
+	}
+}
+
+// EnrichCar fills missing car and car class names of car from the catalog.
+func (c *Catalog) EnrichCar(car *CarRef) {
+	c.enrichCarNames(car.CarID, &car.CarName, car.CarClassID, &car.CarClassName)
+}
+
+// EnrichSchedule fills missing track names of the schedule entries.
+func (c *Catalog) EnrichSchedule(s *ScheduleResponse) {
+	for i := range s.Schedules {
+		c.EnrichTrack(&s.Schedules[i].Track)
+	}
+}
+
+// EnrichSeasonResults fills missing track names of the sessions.
+func (c *Catalog) EnrichSeasonResults(r *SeasonResultsResponse) {
+	for i := range r.Data.ResultsList {
+		c.EnrichTrack(&r.Data.ResultsList[i].Track)
+	}
+}
+
+// EnrichSubsession fills missing track, car and car class names of the
+// results (including the results of team drivers).
+func (c *Catalog) EnrichSubsession(s *SubsessionResult) {
+	c.EnrichTrack(&s.Track)
+	for i := range s.SessionResults {
+		results := s.SessionResults[i].Results
+		for j := range results {
+			c.enrichDriverResult(&results[j])
+		}
+	}
+}
+
+// EnrichSearchResults fills missing track, car and car class names.
+func (c *Catalog) EnrichSearchResults(results []SearchResult) {
+	for i := range results {
+		r := &results[i]
+		c.EnrichTrack(&r.Track)
+		c.enrichCarNames(r.CarID, &r.CarName, r.CarClassID, &r.CarClassName)
+	}
+}
+
+func (c *Catalog) enrichDriverResult(r *DriverResult) {
+	c.enrichCarNames(r.CarID, &r.CarName, r.CarClassID, &r.CarClassName)
+	for i := range r.DriverResults {
+		c.enrichDriverResult(&r.DriverResults[i])
+	}
+}
+
+func (c *Catalog) enrichCarNames(
+	carID int,
+	carName *string,
+	carClassID int,
+	carClassName *string,
+) {
+	if *carName == "" {
+		*carName = c.CarName(carID)
+	}
+	if *carClassName == "" {
+		*carClassName = c.CarClassName(carClassID)
+	}
+}
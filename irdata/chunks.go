@@ -0,0 +1,105 @@
+package irdata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mpapenbr/irdata/log"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	// ChunkInfo describes results that are delivered as multiple chunk files.
+	ChunkInfo struct {
+		ChunkSize       int      `json:"chunk_size,omitempty"`
+		NumChunks       int      `json:"num_chunks,omitempty"`
+		Rows            int      `json:"rows,omitempty"`
+		BaseDownloadURL string   `json:"base_download_url,omitempty"`
+		ChunkFileNames  []string `json:"chunk_file_names,omitempty"`
+	}
+	chunkedResponse struct {
+		Type string `json:"type,omitempty"`
+		Data struct {
+			Success   bool      `json:"success,omitempty"`
+			ChunkInfo ChunkInfo `json:"chunk_info"`
+		} `json:"data"`
+	}
+)
+
+// getChunked fetches endpoint and collects the rows of all chunk files
+// referenced by the response.
+func getChunked[T any](
+	ctx context.Context,
+	i *IrData,
+	endpoint string,
+	params url.Values,
+	opts ...CallOption,
+) ([]T, error) {
+	_, ret, err := getChunkedAs[*chunkedResponse, T](
+		ctx,
+		i,
+		endpoint,
+		params,
+		func(r *chunkedResponse) *ChunkInfo { return &r.Data.ChunkInfo },
+		opts...,
+	)
+	return ret, err
+}
+
+// getChunkedAs fetches endpoint as R and collects the rows of all chunk files
+// referenced by the chunk info of the response (a nil info means no rows).
+// If the chunk links of a cached response are expired, the cache entry is
+// dropped and the endpoint is requested again.
+func getChunkedAs[R, T any](
+	ctx context.Context,
+	i *IrData,
+	endpoint string,
+	params url.Values,
+	chunks func(R) *ChunkInfo,
+	opts ...CallOption,
+) (R, []T, error) {
+	var zero R
+	resp, err := GetAs[R](ctx, i, endpoint, params, opts...)
+	if err != nil {
+		return zero, nil, err
+	}
+	ret, err := fetchChunks[T](ctx, i, chunks(resp))
+	if !errors.Is(err, ErrLinkExpired) {
+		return resp, ret, err
+	}
+	uri := requestURI(endpoint, params)
+	log.Debug("chunk links expired, requesting new links", log.String("uri", uri))
+	if err = i.cfg.cache.Delete(uri); err != nil {
+		return zero, nil, err
+	}
+	if resp, err = GetAs[R](ctx, i, endpoint, params, opts...); err != nil {
+		return zero, nil, err
+	}
+	ret, err = fetchChunks[T](ctx, i, chunks(resp))
+	return resp, ret, err
+}
+
+// fetchChunks downloads the chunk files of info and returns the combined rows.
+func fetchChunks[T any](ctx context.Context, i *IrData, info *ChunkInfo) ([]T, error) {
+	if info == nil {
+		return []T{}, nil
+	}
+	ret := make([]T, 0, info.Rows)
+	base := strings.TrimSuffix(info.BaseDownloadURL, "/")
+	for _, name := range info.ChunkFileNames {
+		data, err := i.getS3(ctx, base+"/"+name)
+		if err != nil {
+			return nil, err
+		}
+		var rows []T
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return nil, fmt.Errorf("failed to decode chunk %s: %w", name, err)
+		}
+		ret = append(ret, rows...)
+	}
+	return ret, nil
+}
@@ -0,0 +1,152 @@
+package irdata
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	// Constant is an entry of the constants endpoints
+	Constant struct {
+		Label string `json:"label,omitempty"`
+		Value int    `json:"value"`
+	}
+
+	// Category is the track/license category (see constants/categories)
+	Category int
+	// Division is the division of a driver (see constants/divisions)
+	Division int
+	// EventType is the type of a session (see constants/event_types)
+	EventType int
+)
+
+const (
+	CategoryOval       Category = 1
+	CategoryRoad       Category = 2 // replaced by sports car and formula car
+	CategoryDirtOval   Category = 3
+	CategoryDirtRoad   Category = 4
+	CategorySportsCar  Category = 5
+	CategoryFormulaCar Category = 6
+)
+
+const (
+	DivisionAll    Division = -1
+	Division1      Division = 0
+	Division2      Division = 1
+	Division3      Division = 2
+	Division4      Division = 3
+	Division5      Division = 4
+	Division6      Division = 5
+	Division7      Division = 6
+	Division8      Division = 7
+	Division9      Division = 8
+	Division10     Division = 9
+	DivisionRookie Division = 10
+)
+
+const (
+	EventTypePractice  EventType = 2
+	EventTypeQualify   EventType = 3
+	EventTypeTimeTrial EventType = 4
+	EventTypeRace      EventType = 5
+)
+
+var (
+	categoryNames = map[Category]string{
+		CategoryOval:       "Oval",
+		CategoryRoad:       "Road",
+		CategoryDirtOval:   "Dirt Oval",
+		CategoryDirtRoad:   "Dirt Road",
+		CategorySportsCar:  "Sports Car",
+		CategoryFormulaCar: "Formula Car",
+	}
+	// maps the track_type values of seasons to categories
+	trackTypeCategories = map[string]Category{
+		"oval":        CategoryOval,
+		"road":        CategoryRoad,
+		"dirt_oval":   CategoryDirtOval,
+		"dirt_road":   CategoryDirtRoad,
+		"sports_car":  CategorySportsCar,
+		"formula_car": CategoryFormulaCar,
+	}
+	eventTypeNames = map[EventType]string{
+		EventTypePractice:  "Practice",
+		EventTypeQualify:   "Qualify",
+		EventTypeTimeTrial: "Time Trial",
+		EventTypeRace:      "Race",
+	}
+)
+
+func (c Category) String() string {
+	if s, ok := categoryNames[c]; ok {
+		return s
+	}
+	return fmt.Sprintf("Category(%d)", int(c))
+}
+
+// CategoryFromTrackType returns the category for a track type
+// like "sports_car" or "dirt_oval".
+func CategoryFromTrackType(trackType string) (Category, bool) {
+	c, ok := trackTypeCategories[trackType]
+	return c, ok
+}
+
+// TrackType returns the track type of the category (for example "sports_car").
+// This is the notation used in paths and track types of seasons.
+func (c Category) TrackType() string {
+	for k, v := range trackTypeCategories {
+		if v == c {
+			return k
+		}
+	}
+	return ""
+}
+
+func (d Division) String() string {
+	switch {
+	case d == DivisionAll:
+		return "All"
+	case d == DivisionRookie:
+		return "Rookie"
+	case d >= Division1 && d <= Division10:
+		return fmt.Sprintf("Division %d", int(d)+1)
+	default:
+		return fmt.Sprintf("Division(%d)", int(d))
+	}
+}
+
+func (e EventType) String() string {
+	if s, ok := eventTypeNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("EventType(%d)", int(e))
+}
+
+func (i *IrData) Categories(
+	ctx context.Context,
+	opts ...CallOption,
+) ([]Constant, error) {
+	return i.constants(ctx, "/data/constants/categories", opts...)
+}
+
+func (i *IrData) Divisions(
+	ctx context.Context,
+	opts ...CallOption,
+) ([]Constant, error) {
+	return i.constants(ctx, "/data/constants/divisions", opts...)
+}
+
+func (i *IrData) EventTypes(
+	ctx context.Context,
+	opts ...CallOption,
+) ([]Constant, error) {
+	return i.constants(ctx, "/data/constants/event_types", opts...)
+}
+
+func (i *IrData) constants(
+	ctx context.Context,
+	endpoint string,
+	opts ...CallOption,
+) ([]Constant, error) {
+	return GetAs[[]Constant](ctx, i, endpoint, nil, opts...)
+}
@@ -0,0 +1,270 @@
+package irdata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mpapenbr/irdata/log"
+)
+
+type (
+	// DecodeMode controls how responses are decoded into typed models
+	DecodeMode int
+
+	// SchemaDriftError is returned in DecodeStrict mode if the response
+	// contains fields not covered by the typed model. Payload contains the
+	// response as delivered by the data API.
+	SchemaDriftError struct {
+		Endpoint string
+		Fields   []string
+		Payload  json.RawMessage
+	}
+
+	// schemaDrift collects the unknown fields per endpoint
+	schemaDrift struct {
+		mu     sync.Mutex
+		fields map[string]map[string]struct{}
+	}
+)
+
+const (
+	// DecodeLenient ignores fields not covered by the typed model
+	DecodeLenient DecodeMode = iota
+	// DecodeReport decodes like DecodeLenient but logs and collects
+	// fields not covered by the typed model (see IrData.SchemaDrift)
+	DecodeReport
+	// DecodeStrict is like DecodeReport but returns a SchemaDriftError
+	DecodeStrict
+)
+
+func (e *SchemaDriftError) Error() string {
+	return fmt.Sprintf("schema drift in %s: unknown fields %s",
+		e.Endpoint, strings.Join(e.Fields, ", "))
+}
+
+// ParseDecodeMode returns the decode mode for lenient, report or strict
+func ParseDecodeMode(s string) (DecodeMode, error) {
+	switch strings.ToLower(s) {
+	case "", "lenient":
+		return DecodeLenient, nil
+	case "report":
+		return DecodeReport, nil
+	case "strict":
+		return DecodeStrict, nil
+	default:
+		return DecodeLenient, fmt.Errorf("unknown decode mode: %s", s)
+	}
+}
+
+func WithDecodeMode(arg DecodeMode) Option {
+	return func(c *config) {
+		c.decodeMode = arg
+	}
+}
+
+// GetAs fetches endpoint with the given query params and decodes the
+// JSON result into T according to the decode mode of the client.
+// If T embeds RawJSON, the original payload is retained (see WithRawPayload).
+// opts control the cache usage of the call.
+func GetAs[T any](
+	ctx context.Context,
+	client *IrData,
+	endpoint string,
+	params url.Values,
+	opts ...CallOption,
+) (T, error) {
+	var ret T
+	data, err := client.get(ctx, requestURI(endpoint, params), opts...)
+	if err != nil {
+		return ret, err
+	}
+	if err := client.decode(endpoint, data, &ret); err != nil {
+		var zero T
+		return zero, err
+	}
+	if r, ok := any(ret).(rawRetainer); ok && client.cfg.rawPayload {
+		r.setRaw(data)
+	}
+	return ret, nil
+}
+
+// SchemaDrift returns the fields not covered by the typed models per endpoint
+// that were found since the client was created (modes DecodeReport and
+// DecodeStrict only).
+func (i *IrData) SchemaDrift() map[string][]string {
+	i.drift.mu.Lock()
+	defer i.drift.mu.Unlock()
+	ret := make(map[string][]string, len(i.drift.fields))
+	for endpoint, fields := range i.drift.fields {
+		for f := range fields {
+			ret[endpoint] = append(ret[endpoint], f)
+		}
+		slices.Sort(ret[endpoint])
+	}
+	return ret
+}
+
+func (i *IrData) decode(endpoint string, data []byte, target any) error {
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", endpoint, err)
+	}
+	if i.cfg.decodeMode == DecodeLenient {
+		return nil
+	}
+	fields, err := UnknownFields(data, target)
+	if err != nil {
+		return fmt.Errorf("failed to check %s for unknown fields: %w", endpoint, err)
+	}
+	if newFields := i.drift.add(endpoint, fields); len(newFields) > 0 {
+		log.Warn("schema drift detected",
+			log.String("endpoint", endpoint),
+			log.Any("fields", newFields))
+	}
+	if i.cfg.decodeMode == DecodeStrict && len(fields) > 0 {
+		return &SchemaDriftError{Endpoint: endpoint, Fields: fields, Payload: data}
+	}
+	return nil
+}
+
+// add records fields for endpoint and returns the fields not seen before.
+func (s *schemaDrift) add(endpoint string, fields []string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fields == nil {
+		s.fields = map[string]map[string]struct{}{}
+	}
+	known, ok := s.fields[endpoint]
+	if !ok {
+		known = map[string]struct{}{}
+		s.fields[endpoint] = known
+	}
+	ret := []string{}
+	for _, f := range fields {
+		if _, ok := known[f]; !ok {
+			known[f] = struct{}{}
+			ret = append(ret, f)
+		}
+	}
+	return ret
+}
+
+// UnknownFields returns the paths of all fields in data that are not covered
+// by the type of target, for example "sessions[].track.track_map".
+func UnknownFields(data []byte, target any) ([]string, error) {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	found := map[string]struct{}{}
+	collectUnknown(raw, reflect.TypeOf(target), "", found)
+	ret := make([]string, 0, len(found))
+	for f := range found {
+		ret = append(ret, f)
+	}
+	slices.Sort(ret)
+	return ret, nil
+}
+
+var unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+func collectUnknown(raw any, t reflect.Type, path string, found map[string]struct{}) {
+	for t.Kind() == reflect.Pointer {
+		if t.Implements(unmarshalerType) {
+			return
+		}
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+	switch v := raw.(type) {
+	case map[string]any:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for key, val := range v {
+				ft, ok := lookupField(fields, key)
+				if !ok {
+					found[joinPath(path, key)] = struct{}{}
+					continue
+				}
+				collectUnknown(val, ft, joinPath(path, key), found)
+			}
+		case reflect.Map:
+			for _, val := range v {
+				collectUnknown(val, t.Elem(), path+"{}", found)
+			}
+		default:
+		}
+	case []any:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, val := range v {
+				collectUnknown(val, t.Elem(), path+"[]", found)
+			}
+		}
+	default:
+	}
+}
+
+// jsonFields returns the types of the fields of struct type t by their
+// JSON name. Fields of embedded structs are included.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	ret := map[string]reflect.Type{}
+	for idx := range t.NumField() {
+		f := t.Field(idx)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					if _, ok := ret[k]; !ok {
+						ret[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		ret[name] = f.Type
+	}
+	return ret
+}
+
+// lookupField returns the type of the field for key. Like encoding/json an
+// exact match is preferred, otherwise keys are matched case-insensitively.
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if ft, ok := fields[key]; ok {
+		return ft, true
+	}
+	for name, ft := range fields {
+		if strings.EqualFold(name, key) {
+			return ft, true
+		}
+	}
+	return nil, false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,87 @@
+package irdata
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+//nolint:tagliatelle // external definition
+type driftTarget struct {
+	CarID   int    `json:"car_id"`
+	CarName string `json:"car_name"`
+	Track   struct {
+		TrackID int `json:"track_id"`
+	} `json:"track"`
+	Laps []struct {
+		LapTime LapTime `json:"lap_time"`
+	} `json:"laps"`
+}
+
+func TestUnknownFields(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{"known", `{"car_id":1,"track":{"track_id":2},"laps":[{"lap_time":1}]}`, nil},
+		{"case-insensitive", `{"Car_ID":1,"CAR_NAME":"x","Track":{"Track_Id":2}}`, nil},
+		{"top level", `{"car_id":1,"car_types":[]}`, []string{"car_types"}},
+		{"nested", `{"track":{"track_id":2,"config":"x"}}`, []string{"track.config"}},
+		{
+			"slice",
+			`{"laps":[{"lap_time":1,"flags":0},{"incident":true}]}`,
+			[]string{"laps[].flags", "laps[].incident"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnknownFields([]byte(tt.data), &driftTarget{})
+			if err != nil {
+				t.Fatalf("UnknownFields() error = %v", err)
+			}
+			if len(got) == 0 {
+				got = nil
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantFields []string
+	}{
+		{"known", `{"car_id":1}`, nil},
+		{"other case", `{"Car_Id":1,"CAR_NAME":"x"}`, nil},
+		{"unknown", `{"car_id":1,"hp":100}`, []string{"hp"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, err := NewIrData(WithDecodeMode(DecodeStrict))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var target driftTarget
+			err = i.decode("/data/car/get", []byte(tt.data), &target)
+			var drift *SchemaDriftError
+			if errors.As(err, &drift) {
+				if !slices.Equal(drift.Fields, tt.wantFields) {
+					t.Errorf("fields = %v, want %v", drift.Fields, tt.wantFields)
+				}
+				if string(drift.Payload) != tt.data {
+					t.Errorf("payload = %s, want %s", drift.Payload, tt.data)
+				}
+			} else if err != nil || tt.wantFields != nil {
+				t.Errorf("decode() error = %v, want drift in %v", err, tt.wantFields)
+			}
+			if target.CarID != 1 {
+				t.Errorf("car_id = %d, want 1", target.CarID)
+			}
+		})
+	}
+}
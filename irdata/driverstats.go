@@ -0,0 +1,161 @@
+package irdata
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type (
+	// DriverStats is a row of the driver stats CSV files
+	DriverStats struct {
+		Driver       string
+		CustID       int
+		Location     string
+		Region       string
+		ClubName     string
+		IRating      int
+		TTRating     int
+		LicenseClass string // R, D, C, B, A, P
+		SafetyRating float64
+		Starts       int
+		Wins         int
+		AvgStart     int
+		AvgFinish    int
+		AvgPoints    int
+		Top25Pcnt    int
+		Laps         int
+		LapsLead     int
+		AvgInc       float64
+	}
+
+	// DriverStatsReader reads DriverStats row by row from a CSV source.
+	// Columns are identified by the header row, unknown columns are ignored.
+	DriverStatsReader struct {
+		r      *csv.Reader
+		src    io.Reader
+		cols   map[string]int
+		record []string
+	}
+)
+
+// DriverStatsByCategory returns a reader for the driver stats of a category.
+// The data is streamed while reading. The reader must be closed after use.
+func (i *IrData) DriverStatsByCategory(
+	ctx context.Context,
+	category Category,
+	opts ...CallOption,
+) (*DriverStatsReader, error) {
+	path := category.TrackType()
+	if path == "" {
+		return nil, fmt.Errorf("no driver stats for category %s", category)
+	}
+	rc, err := i.GetStream(ctx, "/data/driver_stats_by_category/"+path, opts...)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := NewDriverStatsReader(rc)
+	if err != nil {
+		rc.Close()
+		return nil, err
+	}
+	return ret, nil
+}
+
+// NewDriverStatsReader creates a reader on src. The header row is read
+// immediately. If src is an io.Closer, it is closed by Close.
+func NewDriverStatsReader(src io.Reader) (*DriverStatsReader, error) {
+	r := csv.NewReader(src)
+	r.ReuseRecord = true
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+	cols := make(map[string]int, len(header))
+	for idx, name := range header {
+		cols[strings.ToUpper(strings.TrimSpace(name))] = idx
+	}
+	return &DriverStatsReader{r: r, src: src, cols: cols}, nil
+}
+
+// Next returns the next row. At the end of the data io.EOF is returned.
+func (d *DriverStatsReader) Next() (*DriverStats, error) {
+	record, err := d.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	d.record = record
+	ret := &DriverStats{
+		Driver:    d.strVal("DRIVER"),
+		CustID:    d.intVal("CUSTID"),
+		Location:  d.strVal("LOCATION"),
+		Region:    d.strVal("REGION"),
+		ClubName:  d.strVal("CLUB_NAME"),
+		IRating:   d.intVal("IRATING"),
+		TTRating:  d.intVal("TTRATING"),
+		Starts:    d.intVal("STARTS"),
+		Wins:      d.intVal("WINS"),
+		AvgStart:  d.intVal("AVG_START"),
+		AvgFinish: d.intVal("AVG_FINISH"),
+		AvgPoints: d.intVal("AVG_POINTS"),
+		Top25Pcnt: d.intVal("TOP25PCNT"),
+		Laps:      d.intVal("LAPS"),
+		LapsLead:  d.intVal("LAPSLEAD"),
+		AvgInc:    d.floatVal("AVG_INC"),
+	}
+	// CLASS contains license class and safety rating, for example "A 4.99"
+	if class, sr, ok := strings.Cut(d.strVal("CLASS"), " "); ok {
+		ret.LicenseClass = class
+		ret.SafetyRating, _ = strconv.ParseFloat(sr, 64)
+	} else {
+		ret.LicenseClass = class
+	}
+	return ret, nil
+}
+
+// ForEach calls fn for each remaining row. Processing stops on the first error
+// returned by fn.
+func (d *DriverStatsReader) ForEach(fn func(*DriverStats) error) error {
+	for {
+		row, err := d.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+}
+
+func (d *DriverStatsReader) Close() error {
+	if c, ok := d.src.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (d *DriverStatsReader) strVal(col string) string {
+	idx, ok := d.cols[col]
+	if !ok || idx >= len(d.record) {
+		return ""
+	}
+	return strings.TrimSpace(d.record[idx])
+}
+
+func (d *DriverStatsReader) intVal(col string) int {
+	v, _ := strconv.Atoi(d.strVal(col))
+	return v
+}
+
+func (d *DriverStatsReader) floatVal(col string) float64 {
+	v, _ := strconv.ParseFloat(d.strVal(col), 64)
+	return v
+}
@@ -0,0 +1,104 @@
+package irdata
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+type (
+	// flightGroup coalesces concurrent requests of the same key, so
+	// the callers share one upstream request and one S3 download.
+	flightGroup struct {
+		mu      sync.Mutex
+		flights map[string]*flight
+	}
+	flight struct {
+		done chan struct{}
+		res  *fetchResult
+		err  error
+	}
+)
+
+// errNotShared is the result of a flight whose data is not available to
+// the waiting callers, for example a stream that was not read completely.
+// The waiting callers start a request on their own.
+var errNotShared = errors.New("result of call not shared")
+
+// flightKey returns the flight key of calls for the cache key. Calls storing
+// the data in the cache don't share a flight with calls bypassing it.
+func flightKey(key string, store bool) string {
+	if store {
+		return key
+	}
+	return "nocache:" + key
+}
+
+// do calls fn unless a call with the same key is already in progress and
+// waits for the result (or until ctx is done). fn is called in its own
+// goroutine, so it keeps running for the other callers if ctx is done.
+// The returned result is shared between the callers and must not be modified.
+func (g *flightGroup) do(
+	ctx context.Context,
+	key string,
+	fn func() (*fetchResult, error),
+) (*fetchResult, error) {
+	for {
+		f, owner := g.join(key)
+		if owner {
+			go g.run(key, f, fn)
+		}
+		res, err := f.wait(ctx)
+		if !errors.Is(err, errNotShared) {
+			return res, err
+		}
+	}
+}
+
+// join returns the flight in progress for key. If there is none, a new
+// flight is registered and returned with owner set. The owner must
+// finish the flight.
+func (g *flightGroup) join(key string) (f *flight, owner bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if existing, ok := g.flights[key]; ok {
+		return existing, false
+	}
+	if g.flights == nil {
+		g.flights = map[string]*flight{}
+	}
+	f = &flight{done: make(chan struct{})}
+	g.flights[key] = f
+	return f, true
+}
+
+// run calls fn and finishes the flight f with its result
+func (g *flightGroup) run(
+	key string,
+	f *flight,
+	fn func() (*fetchResult, error),
+) (res *fetchResult, err error) {
+	defer func() { g.finish(key, f, res, err) }()
+	return fn()
+}
+
+// finish records the result of f, removes f from the group and releases
+// the waiting callers.
+func (g *flightGroup) finish(key string, f *flight, res *fetchResult, err error) {
+	g.mu.Lock()
+	delete(g.flights, key)
+	g.mu.Unlock()
+	f.res, f.err = res, err
+	close(f.done)
+}
+
+// wait returns the result of f once it is finished (or the error of ctx if
+// ctx is done before).
+func (f *flight) wait(ctx context.Context) (*fetchResult, error) {
+	select {
+	case <-f.done:
+		return f.res, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,156 @@
+package irdata
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlightGroupDo(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	tests := []struct {
+		name      string
+		callers   int
+		err       error
+		wantCalls int32
+	}{
+		{"single caller", 1, nil, 1},
+		{"shared result", 5, nil, 1},
+		{"shared error", 5, errFetch, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var g flightGroup
+			var calls atomic.Int32
+			release := make(chan struct{})
+			fn := func() (*fetchResult, error) {
+				calls.Add(1)
+				<-release
+				if tt.err != nil {
+					return nil, tt.err
+				}
+				return &fetchResult{body: []byte("data")}, nil
+			}
+			var wg sync.WaitGroup
+			errs := make([]error, tt.callers)
+			for c := range tt.callers {
+				wg.Go(func() {
+					res, err := g.do(context.Background(), "key", fn)
+					if err == nil && string(res.body) != "data" {
+						err = errors.New("unexpected body " + string(res.body))
+					}
+					errs[c] = err
+				})
+			}
+			waitForFlight(t, &g, "key")
+			// let the other callers join the flight
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+			wg.Wait()
+
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+			for c, err := range errs {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("caller %d: error = %v, want %v", c, err, tt.err)
+				}
+			}
+			if len(g.flights) != 0 {
+				t.Errorf("flights = %v, want none left", g.flights)
+			}
+		})
+	}
+}
+
+func TestFlightGroupCanceledCaller(t *testing.T) {
+	var g flightGroup
+	release := make(chan struct{})
+	done := make(chan struct{})
+	fn := func() (*fetchResult, error) {
+		defer close(done)
+		<-release
+		return &fetchResult{body: []byte("data")}, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	var ownerErr error
+	wg.Go(func() { _, ownerErr = g.do(ctx, "key", fn) })
+	waitForFlight(t, &g, "key")
+
+	var waiterRes *fetchResult
+	var waiterErr error
+	wg.Go(func() {
+		waiterRes, waiterErr = g.do(context.Background(), "key", fn)
+	})
+	// the owner returns once canceled, the call keeps running for the waiter
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	<-done
+
+	if !errors.Is(ownerErr, context.Canceled) {
+		t.Errorf("owner error = %v, want %v", ownerErr, context.Canceled)
+	}
+	if waiterErr != nil || string(waiterRes.body) != "data" {
+		t.Errorf("waiter = %v, %v, want shared result", waiterRes, waiterErr)
+	}
+}
+
+func TestFlightGroupNotShared(t *testing.T) {
+	var g flightGroup
+	// a stream owning the flight
+	f, owner := g.join("key")
+	if !owner {
+		t.Fatal("join() owner = false for a new key")
+	}
+	var calls atomic.Int32
+	var wg sync.WaitGroup
+	var res *fetchResult
+	var err error
+	wg.Go(func() {
+		res, err = g.do(context.Background(), "key", func() (*fetchResult, error) {
+			calls.Add(1)
+			return &fetchResult{body: []byte("data")}, nil
+		})
+	})
+	time.Sleep(10 * time.Millisecond)
+	// the stream was not read completely, the waiter requests on its own
+	g.finish("key", f, nil, errNotShared)
+	wg.Wait()
+
+	if err != nil || string(res.body) != "data" {
+		t.Errorf("do() = %v, %v, want own result", res, err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+}
+
+func TestFlightKey(t *testing.T) {
+	key := "/data/car/get"
+	if flightKey(key, true) == flightKey(key, false) {
+		t.Errorf("calls storing and bypassing the cache share the flight key %q",
+			flightKey(key, true))
+	}
+}
+
+// waitForFlight waits until a flight for key is registered in g
+func waitForFlight(t *testing.T, g *flightGroup, key string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		g.mu.Lock()
+		_, ok := g.flights[key]
+		g.mu.Unlock()
+		if ok {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("no flight for %s", key)
+}
@@ -0,0 +1,196 @@
+package irdata
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	HostedSessionsResponse struct {
+		Subscribed bool            `json:"subscribed,omitempty"`
+		Success    bool            `json:"success,omitempty"`
+		Sequence   int             `json:"sequence,omitempty"`
+		Sessions   []HostedSession `json:"sessions,omitempty"`
+		RawJSON
+	}
+	HostedSession struct {
+		SessionID         int               `json:"session_id,omitempty"`
+		SubsessionID      int               `json:"subsession_id,omitempty"`
+		PrivateSessionID  int               `json:"private_session_id,omitempty"`
+		SessionName       string            `json:"session_name,omitempty"`
+		SessionDesc       string            `json:"session_desc,omitempty"`
+		LeagueID          int               `json:"league_id,omitempty"`
+		LeagueSeasonID    int               `json:"league_season_id,omitempty"`
+		PasswordProtected bool              `json:"password_protected,omitempty"`
+		Status            int               `json:"status,omitempty"`
+		LaunchAt          IRTime            `json:"launch_at,omitempty"`
+		OpenRegExpires    IRTime            `json:"open_reg_expires,omitempty"`
+		EndTime           IRTime            `json:"end_time,omitempty"`
+		Host              MemberRef         `json:"host"`
+		Admins            []MemberRef       `json:"admins,omitempty"`
+		Track             TrackRef          `json:"track"`
+		Weather           HostedWeather     `json:"weather"`
+		Cars              []HostedCar       `json:"cars,omitempty"`
+		EventTypes        []HostedEventType `json:"event_types,omitempty"`
+		Elig              HostedEligibility `json:"elig"`
+		MaxDrivers        int               `json:"max_drivers,omitempty"`
+		NumDrivers        int               `json:"num_drivers,omitempty"` // registered
+		NumSpectators     int               `json:"num_spectators,omitempty"`
+		TeamEntryCount    int               `json:"team_entry_count,omitempty"`
+		DriverChanges     bool              `json:"driver_changes,omitempty"`
+		HostedSessionSettings
+	}
+	HostedSessionSettings struct {
+		PracticeLength     Minutes `json:"practice_length,omitempty"`
+		QualifyLaps        int     `json:"qualify_laps,omitempty"`
+		QualifyLength      Minutes `json:"qualify_length,omitempty"`
+		LoneQualify        bool    `json:"lone_qualify,omitempty"`
+		WarmupLength       Minutes `json:"warmup_length,omitempty"`
+		RaceLaps           int     `json:"race_laps,omitempty"`
+		RaceLength         Minutes `json:"race_length,omitempty"`
+		TimeLimit          Minutes `json:"time_limit,omitempty"`
+		FullCourseCautions bool    `json:"full_course_cautions,omitempty"`
+		RollingStarts      bool    `json:"rolling_starts,omitempty"`
+		Restarts           int     `json:"restarts,omitempty"`
+		NumFastTows        int     `json:"num_fast_tows,omitempty"`
+		IncidentLimit      int     `json:"incident_limit,omitempty"`
+		DamageModel        int     `json:"damage_model,omitempty"`
+		HardcoreLevel      int     `json:"hardcore_level,omitempty"`
+		MinLicenseLevel    int     `json:"min_license_level,omitempty"`
+		MaxLicenseLevel    int     `json:"max_license_level,omitempty"`
+		MinIR              int     `json:"min_ir,omitempty"`
+		MaxIR              int     `json:"max_ir,omitempty"`
+		MaxAIDrivers       int     `json:"max_ai_drivers,omitempty"`
+	}
+	HostedWeather struct {
+		Type             int    `json:"type,omitempty"`
+		TempUnits        int    `json:"temp_units,omitempty"`
+		TempValue        int    `json:"temp_value,omitempty"`
+		RelHumidity      int    `json:"rel_humidity,omitempty"`
+		Fog              int    `json:"fog,omitempty"`
+		WindDir          int    `json:"wind_dir,omitempty"`
+		WindUnits        int    `json:"wind_units,omitempty"`
+		WindValue        int    `json:"wind_value,omitempty"`
+		Skies            int    `json:"skies,omitempty"`
+		TimeOfDay        int    `json:"time_of_day,omitempty"`
+		SimulatedStartAt IRTime `json:"simulated_start_utc_time,omitempty"`
+	}
+	HostedCar struct {
+		CarRef
+		MaxPctFuelFill  int `json:"max_pct_fuel_fill,omitempty"`
+		WeightPenaltyKg int `json:"weight_penalty_kg,omitempty"`
+		PowerAdjustPct  int `json:"power_adjust_pct,omitempty"`
+		MaxDryTireSets  int `json:"max_dry_tire_sets,omitempty"`
+		PackageID       int `json:"package_id,omitempty"`
+	}
+	HostedEventType struct {
+		EventType EventType `json:"event_type,omitempty"`
+	}
+	HostedEligibility struct {
+		SessionFull     bool `json:"session_full,omitempty"`
+		CanDrive        bool `json:"can_drive,omitempty"`
+		CanSpot         bool `json:"can_spot,omitempty"`
+		CanWatch        bool `json:"can_watch,omitempty"`
+		HasSessPassword bool `json:"has_sess_password,omitempty"`
+		NeedsPurchase   bool `json:"needs_purchase,omitempty"`
+		OwnCar          bool `json:"own_car,omitempty"`
+		OwnTrack        bool `json:"own_track,omitempty"`
+		Registered      bool `json:"registered,omitempty"`
+	}
+)
+
+type (
+	// HostedSessionFilter restricts hosted sessions.
+	// Empty fields don't restrict the result.
+	HostedSessionFilter struct {
+		// case insensitive substring of the session name
+		Name       string
+		HostIDs    []int
+		TrackIDs   []int
+		CarIDs     []int
+		EventTypes []EventType
+		// exclude password protected sessions
+		NoPassword bool
+		MinDrivers int
+	}
+)
+
+// HostedSessions returns the hosted sessions the customer can join as driver.
+func (i *IrData) HostedSessions(
+	ctx context.Context,
+	opts ...CallOption,
+) (*HostedSessionsResponse, error) {
+	return GetAs[*HostedSessionsResponse](ctx, i, "/data/hosted/sessions", nil, opts...)
+}
+
+// HostedCombinedSessions returns the hosted sessions that can be joined as
+// driver or spectator. packageID (optional) restricts the sessions to a
+// track package.
+func (i *IrData) HostedCombinedSessions(
+	ctx context.Context,
+	packageID int,
+	opts ...CallOption,
+) (*HostedSessionsResponse, error) {
+	v := url.Values{}
+	addInt(v, "package_id", packageID)
+	return GetAs[*HostedSessionsResponse](
+		ctx,
+		i,
+		"/data/hosted/combined_sessions",
+		v,
+		opts...,
+	)
+}
+
+// Filter returns the sessions matching f.
+func (r *HostedSessionsResponse) Filter(f *HostedSessionFilter) []HostedSession {
+	ret := make([]HostedSession, 0, len(r.Sessions))
+	for i := range r.Sessions {
+		if f.matches(&r.Sessions[i]) {
+			ret = append(ret, r.Sessions[i])
+		}
+	}
+	return ret
+}
+
+// HasEventType reports whether the session contains an event of type et.
+func (s *HostedSession) HasEventType(et EventType) bool {
+	return slices.ContainsFunc(s.EventTypes,
+		func(e HostedEventType) bool { return e.EventType == et })
+}
+
+// HasCar reports whether the car is allowed in the session.
+func (s *HostedSession) HasCar(carID int) bool {
+	for i := range s.Cars {
+		if s.Cars[i].CarID == carID {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *HostedSessionFilter) matches(s *HostedSession) bool {
+	if f.Name != "" &&
+		!strings.Contains(strings.ToLower(s.SessionName), strings.ToLower(f.Name)) {
+		return false
+	}
+	if len(f.HostIDs) > 0 && !slices.Contains(f.HostIDs, s.Host.CustID) {
+		return false
+	}
+	if len(f.TrackIDs) > 0 && !slices.Contains(f.TrackIDs, s.Track.TrackID) {
+		return false
+	}
+	if len(f.CarIDs) > 0 && !slices.ContainsFunc(f.CarIDs, s.HasCar) {
+		return false
+	}
+	if len(f.EventTypes) > 0 && !slices.ContainsFunc(f.EventTypes, s.HasEventType) {
+		return false
+	}
+	if f.NoPassword && s.PasswordProtected {
+		return false
+	}
+	return s.NumDrivers >= f.MinDrivers
+}
@@ -1,13 +1,14 @@
 package irdata
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
-	"strconv"
 	"sync"
 	"time"
 
@@ -21,9 +22,18 @@ type (
 	Option        func(*config)
 	TokenProvider func() (string, error)
 	config        struct {
-		ctx   context.Context
-		tp    TokenProvider
-		cache cache.Cache
+		ctx        context.Context
+		tp         TokenProvider
+		cache      cache.Cache
+		decodeMode DecodeMode
+		rawPayload bool
+		linkCache  bool
+		maxPayload int64
+		offline    bool
+		serveStale bool
+		callOpts   []CallOption
+
+		streamCacheLimit int64
 	}
 	RateLimit struct {
 		Limit     int
@@ -37,30 +47,72 @@ type (
 		s3Client *retryablehttp.Client
 		rlMutex  sync.Mutex
 		baseURL  *url.URL
+		drift    schemaDrift
+		rl       RateLimit
+		links    linkCache
+		stale    staleSet
+		flights  flightGroup
 	}
 	s3Link struct {
 		Link    string    `json:"link"`
 		Expires time.Time `json:"expires"`
 	}
+	// fetchResult is the data of a request together with its metadata
+	fetchResult struct {
+		body      []byte
+		status    int    // HTTP status of the last response
+		link      string // S3 link the data was downloaded from
+		fetchedAt time.Time
+	}
+
+	// StatusError is returned if the data API or S3 responds with
+	// an unexpected HTTP status.
+	StatusError struct {
+		StatusCode int
+		S3         bool
+	}
 )
 
 const baseURL = "https://members-ng.iracing.com/data"
 
 var ErrNoTokenProvider = fmt.Errorf("no token provider configured")
 
+func (e *StatusError) Error() string {
+	if e.S3 {
+		return fmt.Sprintf("unexpected status code from s3 link: %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// liveEndpoints provide data that changes within minutes.
+// Their responses are neither read from nor written to the cache.
+var liveEndpoints = map[string]bool{
+	"/data/hosted/sessions":                       true,
+	"/data/hosted/combined_sessions":              true,
+	"/data/season/race_guide":                     true,
+	"/data/season/spectator_subsessionids":        true,
+	"/data/season/spectator_subsessionids_detail": true,
+}
+
 func NewIrData(opts ...Option) (*IrData, error) {
 	cfg := config{
 		ctx:   context.Background(),
 		tp:    func() (string, error) { return "", ErrNoTokenProvider },
 		cache: cache.NewNoopCache(),
+
+		streamCacheLimit: defaultStreamCacheLimit,
 	}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	// the last response is passed through once the retries are exhausted,
+	// so server errors are reported as StatusError
 	client := retryablehttp.NewClient()
 	client.Logger = newCustomLeveledLogger(log.Default().Named("irapi"))
+	client.ErrorHandler = retryablehttp.PassthroughErrorHandler
 	s3Client := retryablehttp.NewClient()
 	s3Client.Logger = newCustomLeveledLogger(log.Default().Named("ir-s3"))
+	s3Client.ErrorHandler = retryablehttp.PassthroughErrorHandler
 	parsedBaseURL, err := url.Parse(baseURL)
 	if err != nil {
 		return nil, err
@@ -91,29 +143,143 @@ func WithCache(arg cache.Cache) Option {
 	}
 }
 
-//nolint:funlen // much to do here
-func (i *IrData) Get(uri string) ([]byte, error) {
-	if b, ok := i.cfg.cache.Get(uri); ok {
-		return b, nil
+// Get fetches the data for uri. The uri is relative to the data API base URL,
+// for example "/data/series/season_list?season_year=2026&season_quarter=1".
+// Concurrent calls for the same uri share one upstream request.
+func (i *IrData) Get(uri string, opts ...CallOption) ([]byte, error) {
+	return i.get(i.cfg.ctx, uri, opts...)
+}
+
+func (i *IrData) get(
+	ctx context.Context,
+	uri string,
+	opts ...CallOption,
+) ([]byte, error) {
+	resp, err := i.do(ctx, uri, opts)
+	if err != nil {
+		return nil, err
 	}
-	token, err := i.cfg.tp()
+	return resp.Body, nil
+}
+
+// fetchShared fetches uriRef unless o restricts the call to the cache.
+// Concurrent calls for the same key share one request. The request is not
+// canceled with ctx, the other callers may still wait for it.
+// If store is set, the data is written to the cache using key.
+func (i *IrData) fetchShared(
+	ctx context.Context,
+	key string,
+	uriRef *url.URL,
+	o *callOptions,
+	store bool,
+) (*fetchResult, error) {
+	if o.cacheOnly {
+		return nil, fmt.Errorf("%w: %s", ErrNotCached, key)
+	}
+	fetchCtx := context.WithoutCancel(ctx)
+	return i.flights.do(ctx, flightKey(key, store), func() (*fetchResult, error) {
+		res, err := i.fetch(fetchCtx, uriRef)
+		if err != nil || !store {
+			return res, err
+		}
+		i.stale.remove(key)
+		if cacheErr := i.cfg.cache.Set(key, res.body); cacheErr != nil {
+			log.Warn("failed to set cache", log.ErrorField(cacheErr))
+		}
+		return res, nil
+	})
+}
+
+// fetch requests uriRef from the data API (bypassing the cache).
+// Links to the S3 storage are resolved. The result is returned on errors too.
+func (i *IrData) fetch(ctx context.Context, uriRef *url.URL) (*fetchResult, error) {
+	res := &fetchResult{fetchedAt: time.Now()}
+	rc, err := i.open(ctx, uriRef, res)
+	if err != nil {
+		var se *StatusError
+		if errors.As(err, &se) {
+			res.status = se.StatusCode
+		}
+		return res, err
+	}
+	defer rc.Close()
+	res.status = http.StatusOK
+	res.body, err = io.ReadAll(rc)
+	return res, err
+}
+
+// open requests uriRef from the data API (bypassing the cache) and returns
+// a reader on the payload. Links to the S3 storage are resolved and recorded
+// in res. If a link is expired or rejected by S3, a fresh link is requested
+// once.
+func (i *IrData) open(
+	ctx context.Context,
+	uriRef *url.URL,
+	res *fetchResult,
+) (io.ReadCloser, error) {
+	uri := uriRef.String()
+	if i.cfg.offline {
+		return nil, fmt.Errorf("%w: %s", ErrOffline, uri)
+	}
+	if link, ok := i.links.get(uri); ok {
+		res.link = link.Link
+		rc, err := i.openS3(ctx, link.Link)
+		if !errors.Is(err, ErrLinkExpired) {
+			return rc, err
+		}
+		i.links.remove(uri)
+	}
+	rc, err := i.openOnce(ctx, uriRef, res)
+	if errors.Is(err, ErrLinkExpired) {
+		log.Debug("s3 link expired, requesting new link", log.String("uri", uri))
+		i.links.remove(uri)
+		rc, err = i.openOnce(ctx, uriRef, res)
+	}
+	return rc, err
+}
+
+func (i *IrData) openOnce(
+	ctx context.Context,
+	uriRef *url.URL,
+	res *fetchResult,
+) (io.ReadCloser, error) {
+	body, err := i.requestAPI(ctx, uriRef)
 	if err != nil {
 		return nil, err
 	}
+	var s3link s3Link
+	if err := json.Unmarshal(body, &s3link); err != nil || s3link.Link == "" {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	res.link = s3link.Link
+	if s3link.expired(time.Now()) {
+		return nil, ErrLinkExpired
+	}
+	if i.cfg.linkCache {
+		i.links.set(uriRef.String(), s3link)
+	}
+	return i.openS3(ctx, s3link.Link)
+}
 
-	uriRef, err := url.Parse(uri)
+// requestAPI requests uriRef from the data API and returns the response body
+func (i *IrData) requestAPI(ctx context.Context, uriRef *url.URL) ([]byte, error) {
+	token, err := i.cfg.tp()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse URI: %w", err)
+		return nil, err
 	}
+
 	reqURL := i.baseURL.ResolveReference(uriRef)
 
 	req, err := retryablehttp.NewRequestWithContext(
-		i.cfg.ctx,
+		ctx,
 		http.MethodGet, reqURL.String(), http.NoBody)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Authorization", "Bearer "+token)
+	if err = i.waitForRateLimit(ctx); err != nil {
+		return nil, err
+	}
 	resp, err := i.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -125,37 +291,49 @@ func (i *IrData) Get(uri string) ([]byte, error) {
 		log.String("rate-reset", resp.Header.Get("X-RateLimit-Reset")),
 	)
 	defer resp.Body.Close()
-	rateReset, _ := strconv.ParseFloat(resp.Header.Get("X-RateLimit-Reset"), 64)
-	if rateReset > 0 {
-		log.Debug("rate limit reset time",
-			log.String("reset-time", time.Unix(int64(rateReset), 0).String()))
-	}
+	i.updateRateLimit(resp.Header)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
-	body, err := io.ReadAll(resp.Body)
+	rc, err := i.payloadReader(resp.Body)
 	if err != nil {
 		return nil, err
 	}
-	var s3link s3Link
-	if err := json.Unmarshal(body, &s3link); err == nil {
-		s3Resp, err := i.s3Client.Get(s3link.Link)
-		if err != nil {
-			return nil, err
-		}
-		defer s3Resp.Body.Close()
-		if s3Resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("unexpected status code from s3 link: %d",
-				s3Resp.StatusCode)
-		}
-		body, err = io.ReadAll(s3Resp.Body)
-		if err != nil {
-			return nil, err
-		}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// getS3 fetches the data of a link pointing to the S3 storage
+func (i *IrData) getS3(ctx context.Context, link string) ([]byte, error) {
+	if i.cfg.offline {
+		return nil, ErrOffline
+	}
+	rc, err := i.openS3(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// openS3 returns a reader on the data of a link pointing to the S3 storage
+func (i *IrData) openS3(ctx context.Context, link string) (io.ReadCloser, error) {
+	req, err := retryablehttp.NewRequestWithContext(
+		ctx, http.MethodGet, link, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := i.s3Client.Do(req)
+	if err != nil {
+		return nil, err
 	}
-	if cacheErr := i.cfg.cache.Set(uri, body); cacheErr != nil {
-		log.Warn("failed to set cache", log.ErrorField(cacheErr))
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusForbidden {
+			return nil, ErrLinkExpired
+		}
+		return nil, &StatusError{StatusCode: resp.StatusCode, S3: true}
 	}
-	return body, nil
+	return i.payloadReader(resp.Body)
 }
@@ -0,0 +1,137 @@
+package irdata
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	// LapDataResponse is the response of results/lap_data.
+	// Laps contains the rows of all chunk files.
+	LapDataResponse struct {
+		Success         bool           `json:"success,omitempty"`
+		SessionInfo     LapSessionInfo `json:"session_info"`
+		BestLapNum      int            `json:"best_lap_num,omitempty"`
+		BestLapTime     LapTime        `json:"best_lap_time,omitempty"`
+		BestNLapsNum    int            `json:"best_nlaps_num,omitempty"`
+		BestNLapsTime   LapTime        `json:"best_nlaps_time,omitempty"`
+		BestQualLapNum  int            `json:"best_qual_lap_num,omitempty"`
+		BestQualLapTime LapTime        `json:"best_qual_lap_time,omitempty"`
+		ChunkInfo       *ChunkInfo     `json:"chunk_info,omitempty"`
+		LastUpdated     IRTime         `json:"last_updated,omitempty"`
+		GroupID         int            `json:"group_id,omitempty"`
+		CustID          int            `json:"cust_id,omitempty"`
+		Name            string         `json:"name,omitempty"`
+		CarID           int            `json:"car_id,omitempty"`
+		LicenseLevel    int            `json:"license_level,omitempty"`
+		Laps            []Lap          `json:"-"`
+	}
+	// LapChartDataResponse is the response of results/lap_chart_data.
+	// Laps contains the rows of all chunk files, the laps of all drivers.
+	LapChartDataResponse struct {
+		Success         bool           `json:"success,omitempty"`
+		SessionInfo     LapSessionInfo `json:"session_info"`
+		BestLapNum      int            `json:"best_lap_num,omitempty"`
+		BestLapTime     LapTime        `json:"best_lap_time,omitempty"`
+		BestNLapsNum    int            `json:"best_nlaps_num,omitempty"`
+		BestNLapsTime   LapTime        `json:"best_nlaps_time,omitempty"`
+		BestQualLapNum  int            `json:"best_qual_lap_num,omitempty"`
+		BestQualLapTime LapTime        `json:"best_qual_lap_time,omitempty"`
+		ChunkInfo       *ChunkInfo     `json:"chunk_info,omitempty"`
+		LastUpdated     IRTime         `json:"last_updated,omitempty"`
+		Laps            []Lap          `json:"-"`
+	}
+	LapSessionInfo struct {
+		SubsessionID     int       `json:"subsession_id,omitempty"`
+		SessionID        int       `json:"session_id,omitempty"`
+		SimsessionNumber int       `json:"simsession_number"`
+		SimsessionType   int       `json:"simsession_type,omitempty"`
+		SimsessionName   string    `json:"simsession_name,omitempty"`
+		EventType        EventType `json:"event_type,omitempty"`
+		EventTypeName    string    `json:"event_type_name,omitempty"`
+		SeasonName       string    `json:"season_name,omitempty"`
+		SeriesName       string    `json:"series_name,omitempty"`
+		StartTime        IRTime    `json:"start_time,omitempty"`
+	}
+	// Lap is a lap of a driver. For team events GroupID is the team id,
+	// otherwise the customer id.
+	Lap struct {
+		GroupID         int      `json:"group_id,omitempty"`
+		Name            string   `json:"name,omitempty"`
+		CustID          int      `json:"cust_id,omitempty"`
+		DisplayName     string   `json:"display_name,omitempty"`
+		LapNumber       int      `json:"lap_number"`
+		Flags           int      `json:"flags,omitempty"`
+		Incident        bool     `json:"incident,omitempty"`
+		SessionTime     LapTime  `json:"session_time,omitempty"`
+		LapTime         LapTime  `json:"lap_time,omitempty"`
+		TeamFastestLap  bool     `json:"team_fastest_lap,omitempty"`
+		PersonalBestLap bool     `json:"personal_best_lap,omitempty"`
+		LicenseLevel    int      `json:"license_level,omitempty"`
+		CarNumber       string   `json:"car_number,omitempty"`
+		LapEvents       []string `json:"lap_events,omitempty"`
+		LapPosition     int      `json:"lap_position,omitempty"`
+		Interval        LapTime  `json:"interval,omitempty"`
+		IntervalUnits   string   `json:"interval_units,omitempty"`
+		FastestLap      bool     `json:"fastest_lap,omitempty"`
+		AI              bool     `json:"ai,omitempty"`
+	}
+)
+
+// LapData returns the laps of a driver or team in a sim session (0 is the
+// main event) of a subsession. custID is required for single driver events,
+// teamID for team events (custID then restricts the laps to a driver of the
+// team). The laps of all drivers are provided by LapChartData.
+func (i *IrData) LapData(
+	ctx context.Context,
+	subsessionID, simsessionNumber, custID, teamID int,
+	opts ...CallOption,
+) (*LapDataResponse, error) {
+	v := url.Values{}
+	addInt(v, "subsession_id", subsessionID)
+	// simsession 0 is the main event, not a default
+	v.Set("simsession_number", strconv.Itoa(simsessionNumber))
+	addInt(v, "cust_id", custID)
+	addInt(v, "team_id", teamID)
+	resp, laps, err := getChunkedAs[*LapDataResponse, Lap](
+		ctx,
+		i,
+		"/data/results/lap_data",
+		v,
+		func(r *LapDataResponse) *ChunkInfo { return r.ChunkInfo },
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp.Laps = laps
+	return resp, nil
+}
+
+// LapChartData returns the laps of all drivers in a sim session (0 is the
+// main event) of a subsession.
+func (i *IrData) LapChartData(
+	ctx context.Context,
+	subsessionID, simsessionNumber int,
+	opts ...CallOption,
+) (*LapChartDataResponse, error) {
+	v := url.Values{}
+	addInt(v, "subsession_id", subsessionID)
+	// simsession 0 is the main event, not a default
+	v.Set("simsession_number", strconv.Itoa(simsessionNumber))
+	resp, laps, err := getChunkedAs[*LapChartDataResponse, Lap](
+		ctx,
+		i,
+		"/data/results/lap_chart_data",
+		v,
+		func(r *LapChartDataResponse) *ChunkInfo { return r.ChunkInfo },
+		opts...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp.Laps = laps
+	return resp, nil
+}
@@ -0,0 +1,403 @@
+package irdata
+
+import (
+	"context"
+	"net/url"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	MemberRef struct {
+		CustID      int    `json:"cust_id,omitempty"`
+		DisplayName string `json:"display_name,omitempty"`
+		CarNumber   string `json:"car_number,omitempty"`
+		NickName    string `json:"nick_name,omitempty"`
+	}
+	TrackRef struct {
+		TrackID    int    `json:"track_id,omitempty"`
+		TrackName  string `json:"track_name,omitempty"`
+		ConfigName string `json:"config_name,omitempty"`
+	}
+	CarRef struct {
+		CarID        int    `json:"car_id,omitempty"`
+		CarName      string `json:"car_name,omitempty"`
+		CarClassID   int    `json:"car_class_id,omitempty"`
+		CarClassName string `json:"car_class_name,omitempty"`
+	}
+
+	League struct {
+		LeagueID        int            `json:"league_id,omitempty"`
+		OwnerID         int            `json:"owner_id,omitempty"`
+		LeagueName      string         `json:"league_name,omitempty"`
+		Created         IRTime         `json:"created,omitempty"`
+		About           string         `json:"about,omitempty"`
+		URL             string         `json:"url,omitempty"`
+		Hidden          bool           `json:"hidden,omitempty"`
+		Recruiting      bool           `json:"recruiting,omitempty"`
+		PrivateRoster   bool           `json:"private_roster,omitempty"`
+		PrivateSchedule bool           `json:"private_schedule,omitempty"`
+		PrivateResults  bool           `json:"private_results,omitempty"`
+		IsOwner         bool           `json:"is_owner,omitempty"`
+		IsAdmin         bool           `json:"is_admin,omitempty"`
+		IsMember        bool           `json:"is_member,omitempty"`
+		RosterCount     int            `json:"roster_count,omitempty"`
+		Owner           MemberRef      `json:"owner"`
+		Roster          []LeagueMember `json:"roster,omitempty"`
+		RawJSON
+	}
+	LeagueMember struct {
+		CustID            int    `json:"cust_id,omitempty"`
+		DisplayName       string `json:"display_name,omitempty"`
+		Owner             bool   `json:"owner,omitempty"`
+		Admin             bool   `json:"admin,omitempty"`
+		LeagueMemberSince IRDate `json:"league_member_since,omitempty"`
+		CarNumber         string `json:"car_number,omitempty"`
+		NickName          string `json:"nick_name,omitempty"`
+	}
+	LeagueMembership struct {
+		LeagueID   int    `json:"league_id,omitempty"`
+		LeagueName string `json:"league_name,omitempty"`
+		Owner      bool   `json:"owner,omitempty"`
+		Admin      bool   `json:"admin,omitempty"`
+		CarNumber  string `json:"car_number,omitempty"`
+		NickName   string `json:"nick_name,omitempty"`
+	}
+	LeagueRosterResponse struct {
+		LeagueID    int            `json:"league_id,omitempty"`
+		Success     bool           `json:"success,omitempty"`
+		RosterCount int            `json:"roster_count,omitempty"`
+		Roster      []LeagueMember `json:"roster,omitempty"`
+		RawJSON
+	}
+
+	LeagueSeasonsResponse struct {
+		LeagueID int            `json:"league_id,omitempty"`
+		Success  bool           `json:"success,omitempty"`
+		Retired  bool           `json:"retired,omitempty"`
+		Seasons  []LeagueSeason `json:"seasons,omitempty"`
+		RawJSON
+	}
+	LeagueSeason struct {
+		LeagueID         int    `json:"league_id,omitempty"`
+		SeasonID         int    `json:"season_id,omitempty"`
+		SeasonName       string `json:"season_name,omitempty"`
+		Active           bool   `json:"active,omitempty"`
+		Hidden           bool   `json:"hidden,omitempty"`
+		PointsSystemID   int    `json:"points_system_id,omitempty"`
+		PointsSystemName string `json:"points_system_name,omitempty"`
+		NumDrops         int    `json:"num_drops,omitempty"`
+	}
+
+	LeagueSeasonSessionsResponse struct {
+		LeagueID    int             `json:"league_id,omitempty"`
+		SeasonID    int             `json:"season_id,omitempty"`
+		Success     bool            `json:"success,omitempty"`
+		ResultsOnly bool            `json:"results_only,omitempty"`
+		Sessions    []LeagueSession `json:"sessions,omitempty"`
+		RawJSON
+	}
+	LeagueSession struct {
+		SessionID         int      `json:"session_id,omitempty"`
+		SubsessionID      int      `json:"subsession_id,omitempty"`
+		PrivateSessionID  int      `json:"private_session_id,omitempty"`
+		LeagueID          int      `json:"league_id,omitempty"`
+		LeagueSeasonID    int      `json:"league_season_id,omitempty"`
+		LaunchAt          IRTime   `json:"launch_at,omitempty"`
+		Status            int      `json:"status,omitempty"`
+		HasResults        bool     `json:"has_results,omitempty"`
+		PasswordProtected bool     `json:"password_protected,omitempty"`
+		EntryCount        int      `json:"entry_count,omitempty"`
+		TeamEntryCount    int      `json:"team_entry_count,omitempty"`
+		PracticeLength    Minutes  `json:"practice_length,omitempty"`
+		QualifyLength     Minutes  `json:"qualify_length,omitempty"`
+		RaceLength        Minutes  `json:"race_length,omitempty"`
+		RaceLaps          int      `json:"race_laps,omitempty"`
+		WinnerID          int      `json:"winner_id,omitempty"`
+		WinnerName        string   `json:"winner_name,omitempty"`
+		Track             TrackRef `json:"track"`
+		Cars              []CarRef `json:"cars,omitempty"`
+	}
+
+	LeagueSeasonStandingsResponse struct {
+		LeagueID   int             `json:"league_id,omitempty"`
+		SeasonID   int             `json:"season_id,omitempty"`
+		CarClassID int             `json:"car_class_id,omitempty"`
+		CarID      int             `json:"car_id,omitempty"`
+		Success    bool            `json:"success,omitempty"`
+		Standings  LeagueStandings `json:"standings"`
+		RawJSON
+	}
+	LeagueStandings struct {
+		DriverStandings []LeagueDriverStanding `json:"driver_standings,omitempty"`
+		TeamStandings   []LeagueTeamStanding   `json:"team_standings,omitempty"`
+		DriverCsvURL    string                 `json:"driver_standings_csv_url,omitempty"`
+		TeamCsvURL      string                 `json:"team_standings_csv_url,omitempty"`
+	}
+	LeagueDriverStanding struct {
+		Rownum         int       `json:"rownum,omitempty"`
+		Position       int       `json:"position,omitempty"`
+		Driver         MemberRef `json:"driver"`
+		CarNumber      string    `json:"car_number,omitempty"`
+		DriverNickname string    `json:"driver_nickname,omitempty"`
+		Wins           int       `json:"wins,omitempty"`
+		AverageStart   int       `json:"average_start,omitempty"`
+		AverageFinish  int       `json:"average_finish,omitempty"`
+		BasePoints     int       `json:"base_points,omitempty"`
+		TotalPoints    int       `json:"total_points,omitempty"`
+	}
+	LeagueTeamStanding struct {
+		Rownum        int    `json:"rownum,omitempty"`
+		Position      int    `json:"position,omitempty"`
+		TeamID        int    `json:"team_id,omitempty"`
+		TeamName      string `json:"team_name,omitempty"`
+		Wins          int    `json:"wins,omitempty"`
+		AverageStart  int    `json:"average_start,omitempty"`
+		AverageFinish int    `json:"average_finish,omitempty"`
+		BasePoints    int    `json:"base_points,omitempty"`
+		TotalPoints   int    `json:"total_points,omitempty"`
+	}
+
+	LeaguePointsSystemsResponse struct {
+		LeagueID      int                  `json:"league_id,omitempty"`
+		Success       bool                 `json:"success,omitempty"`
+		PointsSystems []LeaguePointsSystem `json:"points_systems,omitempty"`
+		RawJSON
+	}
+	LeaguePointsSystem struct {
+		PointsSystemID int    `json:"points_system_id,omitempty"`
+		LeagueID       int    `json:"league_id,omitempty"`
+		Name           string `json:"name,omitempty"`
+		Description    string `json:"description,omitempty"`
+		Retired        bool   `json:"retired,omitempty"`
+		IracingSystem  bool   `json:"iracing_system,omitempty"`
+	}
+
+	CustLeagueSessionsResponse struct {
+		Mine     bool                `json:"mine,omitempty"`
+		Success  bool                `json:"success,omitempty"`
+		Sessions []CustLeagueSession `json:"sessions,omitempty"`
+		RawJSON
+	}
+	CustLeagueSession struct {
+		SessionID         int       `json:"session_id,omitempty"`
+		SubsessionID      int       `json:"subsession_id,omitempty"`
+		PrivateSessionID  int       `json:"private_session_id,omitempty"`
+		LeagueID          int       `json:"league_id,omitempty"`
+		LeagueSeasonID    int       `json:"league_season_id,omitempty"`
+		SessionName       string    `json:"session_name,omitempty"`
+		LaunchAt          IRTime    `json:"launch_at,omitempty"`
+		Status            int       `json:"status,omitempty"`
+		PasswordProtected bool      `json:"password_protected,omitempty"`
+		Host              MemberRef `json:"host"`
+		Track             TrackRef  `json:"track"`
+		Cars              []CarRef  `json:"cars,omitempty"`
+	}
+
+	LeagueDirectoryResponse struct {
+		Success     bool                   `json:"success,omitempty"`
+		Lowerbound  int                    `json:"lowerbound,omitempty"`
+		Upperbound  int                    `json:"upperbound,omitempty"`
+		RowCount    int                    `json:"row_count,omitempty"`
+		ResultsPage []LeagueDirectoryEntry `json:"results_page,omitempty"`
+		RawJSON
+	}
+	LeagueDirectoryEntry struct {
+		LeagueID           int       `json:"league_id,omitempty"`
+		OwnerID            int       `json:"owner_id,omitempty"`
+		LeagueName         string    `json:"league_name,omitempty"`
+		Created            IRTime    `json:"created,omitempty"`
+		About              string    `json:"about,omitempty"`
+		URL                string    `json:"url,omitempty"`
+		RosterCount        int       `json:"roster_count,omitempty"`
+		Recruiting         bool      `json:"recruiting,omitempty"`
+		IsAdmin            bool      `json:"is_admin,omitempty"`
+		IsMember           bool      `json:"is_member,omitempty"`
+		PendingApplication bool      `json:"pending_application,omitempty"`
+		PendingInvitation  bool      `json:"pending_invitation,omitempty"`
+		Owner              MemberRef `json:"owner"`
+	}
+)
+
+type (
+	// LeagueDirectoryParams holds the optional filters of the league directory.
+	// Zero values are not sent to the API.
+	LeagueDirectoryParams struct {
+		Search               string
+		Tag                  string
+		RestrictToMember     bool
+		RestrictToRecruiting bool
+		RestrictToFriends    bool
+		RestrictToWatched    bool
+		MinimumRosterCount   int
+		MaximumRosterCount   int
+		Lowerbound           int
+		Upperbound           int
+		Sort                 string // relevance, leaguename, displayname, rostercount
+		Order                string // asc, desc
+	}
+)
+
+func (p *LeagueDirectoryParams) values() url.Values {
+	v := url.Values{}
+	addString(v, "search", p.Search)
+	addString(v, "tag", p.Tag)
+	addBool(v, "restrict_to_member", p.RestrictToMember)
+	addBool(v, "restrict_to_recruiting", p.RestrictToRecruiting)
+	addBool(v, "restrict_to_friends", p.RestrictToFriends)
+	addBool(v, "restrict_to_watched", p.RestrictToWatched)
+	addInt(v, "minimum_roster_count", p.MinimumRosterCount)
+	addInt(v, "maximum_roster_count", p.MaximumRosterCount)
+	addInt(v, "lowerbound", p.Lowerbound)
+	addInt(v, "upperbound", p.Upperbound)
+	addString(v, "sort", p.Sort)
+	addString(v, "order", p.Order)
+	return v
+}
+
+// League returns the league including its roster.
+func (i *IrData) League(
+	ctx context.Context,
+	leagueID int,
+	includeLicenses bool,
+	opts ...CallOption,
+) (*League, error) {
+	v := url.Values{}
+	addInt(v, "league_id", leagueID)
+	addBool(v, "include_licenses", includeLicenses)
+	return GetAs[*League](ctx, i, "/data/league/get", v, opts...)
+}
+
+func (i *IrData) LeagueRoster(
+	ctx context.Context,
+	leagueID int,
+	includeLicenses bool,
+	opts ...CallOption,
+) (*LeagueRosterResponse, error) {
+	v := url.Values{}
+	addInt(v, "league_id", leagueID)
+	addBool(v, "include_licenses", includeLicenses)
+	return GetAs[*LeagueRosterResponse](ctx, i, "/data/league/roster", v, opts...)
+}
+
+// LeagueMemberships returns the leagues the customer is a member of.
+// custID 0 means the authenticated customer. Memberships of other customers
+// are only returned if their league memberships are public.
+func (i *IrData) LeagueMemberships(
+	ctx context.Context,
+	custID int,
+	opts ...CallOption,
+) ([]LeagueMembership, error) {
+	v := url.Values{}
+	addInt(v, "cust_id", custID)
+	return GetAs[[]LeagueMembership](ctx, i, "/data/league/membership", v, opts...)
+}
+
+// LeagueSeasons returns the seasons of a league. If retired is true the
+// retired seasons are returned instead of the active ones.
+func (i *IrData) LeagueSeasons(
+	ctx context.Context,
+	leagueID int,
+	retired bool,
+	opts ...CallOption,
+) (*LeagueSeasonsResponse, error) {
+	v := url.Values{}
+	addInt(v, "league_id", leagueID)
+	addBool(v, "retired", retired)
+	return GetAs[*LeagueSeasonsResponse](ctx, i, "/data/league/seasons", v, opts...)
+}
+
+func (i *IrData) LeagueSeasonSessions(
+	ctx context.Context,
+	leagueID, seasonID int,
+	resultsOnly bool,
+	opts ...CallOption,
+) (*LeagueSeasonSessionsResponse, error) {
+	v := url.Values{}
+	addInt(v, "league_id", leagueID)
+	addInt(v, "season_id", seasonID)
+	addBool(v, "results_only", resultsOnly)
+	return GetAs[*LeagueSeasonSessionsResponse](
+		ctx,
+		i,
+		"/data/league/season_sessions",
+		v,
+		opts...,
+	)
+}
+
+// LeagueSeasonStandings returns the standings of a league season.
+// carClassID and carID are optional filters (0 means no filter).
+func (i *IrData) LeagueSeasonStandings(
+	ctx context.Context,
+	leagueID, seasonID, carClassID, carID int,
+	opts ...CallOption,
+) (*LeagueSeasonStandingsResponse, error) {
+	v := url.Values{}
+	addInt(v, "league_id", leagueID)
+	addInt(v, "season_id", seasonID)
+	addInt(v, "car_class_id", carClassID)
+	addInt(v, "car_id", carID)
+	return GetAs[*LeagueSeasonStandingsResponse](
+		ctx,
+		i,
+		"/data/league/season_standings",
+		v,
+		opts...,
+	)
+}
+
+// LeaguePointsSystems returns the points systems of a league.
+// If seasonID is given, the points system of that season is included as well.
+func (i *IrData) LeaguePointsSystems(
+	ctx context.Context,
+	leagueID, seasonID int,
+	opts ...CallOption,
+) (*LeaguePointsSystemsResponse, error) {
+	v := url.Values{}
+	addInt(v, "league_id", leagueID)
+	addInt(v, "season_id", seasonID)
+	return GetAs[*LeaguePointsSystemsResponse](
+		ctx,
+		i,
+		"/data/league/get_points_systems",
+		v,
+		opts...,
+	)
+}
+
+// CustLeagueSessions returns the league sessions visible to the customer.
+// If mine is true only sessions the customer created or registered for
+// are returned. packageID (optional) restricts the sessions to a track package.
+func (i *IrData) CustLeagueSessions(
+	ctx context.Context,
+	mine bool,
+	packageID int,
+	opts ...CallOption,
+) (*CustLeagueSessionsResponse, error) {
+	v := url.Values{}
+	addBool(v, "mine", mine)
+	addInt(v, "package_id", packageID)
+	return GetAs[*CustLeagueSessionsResponse](
+		ctx,
+		i,
+		"/data/league/cust_league_sessions",
+		v,
+		opts...,
+	)
+}
+
+func (i *IrData) LeagueDirectory(
+	ctx context.Context,
+	p *LeagueDirectoryParams,
+	opts ...CallOption,
+) (*LeagueDirectoryResponse, error) {
+	if p == nil {
+		p = &LeagueDirectoryParams{}
+	}
+	return GetAs[*LeagueDirectoryResponse](
+		ctx,
+		i,
+		"/data/league/directory",
+		p.values(),
+		opts...)
+}
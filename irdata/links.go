@@ -0,0 +1,65 @@
+package irdata
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+type (
+	// linkCache keeps S3 links until they expire, so parallel consumers
+	// of the same endpoint don't need to request a new link each time.
+	linkCache struct {
+		mu    sync.Mutex
+		links map[string]s3Link
+	}
+)
+
+// links are considered expired this long before their actual expiry
+// to leave some time for the download.
+const linkExpiryMargin = 10 * time.Second
+
+// ErrLinkExpired is returned if a S3 link is expired or rejected by S3.
+var ErrLinkExpired = errors.New("s3 link expired")
+
+// WithLinkCache enables keeping S3 links in memory until they expire.
+// Requests of the same uri within this period skip the API call and
+// download the data directly from S3.
+func WithLinkCache(arg bool) Option {
+	return func(c *config) {
+		c.linkCache = arg
+	}
+}
+
+func (l *s3Link) expired(now time.Time) bool {
+	return !l.Expires.IsZero() && now.Add(linkExpiryMargin).After(l.Expires)
+}
+
+func (c *linkCache) get(uri string) (s3Link, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	link, ok := c.links[uri]
+	if !ok {
+		return s3Link{}, false
+	}
+	if link.expired(time.Now()) {
+		delete(c.links, uri)
+		return s3Link{}, false
+	}
+	return link, true
+}
+
+func (c *linkCache) set(uri string, link s3Link) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.links == nil {
+		c.links = map[string]s3Link{}
+	}
+	c.links[uri] = link
+}
+
+func (c *linkCache) remove(uri string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.links, uri)
+}
@@ -1,5 +1,10 @@
 package irdata
 
+import (
+	"context"
+	"net/url"
+)
+
 //nolint:tagliatelle // external definition
 type (
 	LookupResponse struct {
@@ -16,4 +21,85 @@ type (
 		Seq         int    `json:"seq,omitempty"`
 		Value       string `json:"value,omitempty"`
 	}
+
+	Country struct {
+		CountryName string `json:"country_name,omitempty"`
+		CountryCode string `json:"country_code,omitempty"`
+	}
+
+	LicenseGroup struct {
+		LicenseGroup         int            `json:"license_group,omitempty"`
+		GroupName            string         `json:"group_name,omitempty"`
+		MinNumRaces          int            `json:"min_num_races,omitempty"`
+		ParticipationCredits int            `json:"participation_credits,omitempty"`
+		MinSrToFastTrack     int            `json:"min_sr_to_fast_track,omitempty"`
+		MinNumTT             int            `json:"min_num_tt,omitempty"`
+		Levels               []LicenseLevel `json:"levels,omitempty"`
+	}
+	LicenseLevel struct {
+		LicenseID     int    `json:"license_id,omitempty"`
+		LicenseGroup  int    `json:"license_group,omitempty"`
+		License       string `json:"license,omitempty"`
+		ShortName     string `json:"short_name,omitempty"`
+		LicenseLetter string `json:"license_letter,omitempty"`
+		Color         string `json:"color,omitempty"`
+	}
+
+	FlairsResponse struct {
+		Success bool    `json:"success,omitempty"`
+		Flairs  []Flair `json:"flairs,omitempty"`
+		RawJSON
+	}
+	Flair struct {
+		FlairID        int    `json:"flair_id,omitempty"`
+		FlairName      string `json:"flair_name,omitempty"`
+		FlairShortname string `json:"flair_shortname,omitempty"`
+		CountryCode    string `json:"country_code,omitempty"`
+		Seq            int    `json:"seq,omitempty"`
+	}
+
+	DriverSearchResult struct {
+		CustID          int    `json:"cust_id,omitempty"`
+		DisplayName     string `json:"display_name,omitempty"`
+		ProfileDisabled bool   `json:"profile_disabled,omitempty"`
+	}
 )
+
+func (i *IrData) Lookups(
+	ctx context.Context,
+	opts ...CallOption,
+) ([]LookupResponse, error) {
+	return GetAs[[]LookupResponse](ctx, i, "/data/lookup/get", nil, opts...)
+}
+
+func (i *IrData) Countries(ctx context.Context, opts ...CallOption) ([]Country, error) {
+	return GetAs[[]Country](ctx, i, "/data/lookup/countries", nil, opts...)
+}
+
+func (i *IrData) Licenses(
+	ctx context.Context,
+	opts ...CallOption,
+) ([]LicenseGroup, error) {
+	return GetAs[[]LicenseGroup](ctx, i, "/data/lookup/licenses", nil, opts...)
+}
+
+func (i *IrData) Flairs(
+	ctx context.Context,
+	opts ...CallOption,
+) (*FlairsResponse, error) {
+	return GetAs[*FlairsResponse](ctx, i, "/data/lookup/flairs", nil, opts...)
+}
+
+// SearchDrivers searches drivers by cust_id or partial name.
+// leagueID (optional) narrows the search to the roster of a league.
+func (i *IrData) SearchDrivers(
+	ctx context.Context,
+	searchTerm string,
+	leagueID int,
+	opts ...CallOption,
+) ([]DriverSearchResult, error) {
+	v := url.Values{}
+	addString(v, "search_term", searchTerm)
+	addInt(v, "league_id", leagueID)
+	return GetAs[[]DriverSearchResult](ctx, i, "/data/lookup/drivers", v, opts...)
+}
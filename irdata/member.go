@@ -0,0 +1,261 @@
+package irdata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+
+	"github.com/mpapenbr/irdata/cache"
+	"github.com/mpapenbr/irdata/log"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	MembersResponse struct {
+		Success bool     `json:"success,omitempty"`
+		CustIDs []int    `json:"cust_ids,omitempty"`
+		Members []Member `json:"members,omitempty"`
+		RawJSON
+	}
+	Member struct {
+		CustID         int             `json:"cust_id,omitempty"`
+		DisplayName    string          `json:"display_name,omitempty"`
+		LastLogin      IRTime          `json:"last_login,omitempty"`
+		MemberSince    IRDate          `json:"member_since,omitempty"`
+		ClubID         int             `json:"club_id,omitempty"`
+		ClubName       string          `json:"club_name,omitempty"`
+		FlairID        int             `json:"flair_id,omitempty"`
+		FlairName      string          `json:"flair_name,omitempty"`
+		FlairShortname string          `json:"flair_shortname,omitempty"`
+		AI             bool            `json:"ai,omitempty"`
+		Licenses       []MemberLicense `json:"licenses,omitempty"`
+	}
+	MemberLicense struct {
+		CategoryID    Category `json:"category_id,omitempty"`
+		Category      string   `json:"category,omitempty"`
+		LicenseLevel  int      `json:"license_level,omitempty"`
+		SafetyRating  float64  `json:"safety_rating,omitempty"`
+		Irating       int      `json:"irating,omitempty"`
+		TTRating      int      `json:"tt_rating,omitempty"`
+		GroupName     string   `json:"group_name,omitempty"`
+		GroupID       int      `json:"group_id,omitempty"`
+		ProPromotable bool     `json:"pro_promotable,omitempty"`
+	}
+)
+
+const (
+	// MaxMembersPerRequest is the max number of cust_ids per member/get call
+	MaxMembersPerRequest = 50
+	// number of member/get batches fetched concurrently
+	membersConcurrency = 4
+	membersEndpoint    = "/data/member/get"
+)
+
+// Member returns the member with custID.
+func (i *IrData) Member(
+	ctx context.Context,
+	custID int,
+	opts ...CallOption,
+) (*Member, error) {
+	resp, err := GetAs[*MembersResponse](
+		ctx, i, membersEndpoint, memberParams(custID), opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Members) == 0 {
+		return nil, fmt.Errorf("member %d not found", custID)
+	}
+	return &resp.Members[0], nil
+}
+
+// Members returns the members with the given ids indexed by cust_id.
+// Members not in the cache are fetched in batches of MaxMembersPerRequest
+// (expired entries are used for calls restricted to the cache only).
+// If a batch fails and stale entries may be served (see WithServeStale), the
+// cached entries of its members are used instead, provided all are cached.
+// Each member is cached individually, so later calls of Member for the same
+// id are served from the cache. Unknown ids are not included in the result.
+func (i *IrData) Members(
+	ctx context.Context,
+	ids []int,
+	opts ...CallOption,
+) (map[int]Member, error) {
+	o := i.resolveCallOptions(opts)
+	ret := make(map[int]Member, len(ids))
+	missing := make([]int, 0, len(ids))
+	for _, id := range lo.Uniq(ids) {
+		if !o.noCache && !o.refresh && i.cachedMember(id, o, ret) {
+			continue
+		}
+		missing = append(missing, id)
+	}
+	if len(missing) > 0 && o.cacheOnly {
+		return nil, fmt.Errorf("%w: members %v", ErrNotCached, missing)
+	}
+	if err := i.fetchMemberBatches(ctx, missing, o, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// cachedMember adds the cached member with custID to ret if usable
+func (i *IrData) cachedMember(custID int, o *callOptions, ret map[int]Member) bool {
+	entry, ok := i.lookup(memberCacheKey(custID))
+	if !ok || (!o.cacheOnly && !o.usable(entry, time.Now())) {
+		return false
+	}
+	member, ok := i.decodeMember(entry)
+	if ok {
+		ret[custID] = member
+	}
+	return ok
+}
+
+// staleMembers returns the cached entries (even if expired) of the members
+// of a batch that failed with err. ok is false unless all of them are cached
+// and stale entries may be served.
+func (i *IrData) staleMembers(
+	ctx context.Context,
+	ids []int,
+	err error,
+	o *callOptions,
+) (members []Member, ok bool) {
+	members = make([]Member, 0, len(ids))
+	for _, id := range ids {
+		entry, cached := i.lookup(memberCacheKey(id))
+		if !cached {
+			return nil, false
+		}
+		member, decoded := i.decodeMember(entry)
+		if !decoded {
+			return nil, false
+		}
+		members = append(members, member)
+	}
+	for _, id := range ids {
+		if !i.fallbackStale(ctx, memberCacheKey(id), err, o) {
+			return nil, false
+		}
+	}
+	return members, true
+}
+
+// decodeMember returns the member of a cached member/get response
+func (i *IrData) decodeMember(entry *cache.Entry) (Member, bool) {
+	var resp MembersResponse
+	if err := i.decode(membersEndpoint, entry.Value, &resp); err != nil ||
+		len(resp.Members) == 0 {
+		return Member{}, false
+	}
+	return resp.Members[0], true
+}
+
+// fetchMemberBatches fetches the members concurrently and adds them to ret.
+// The first error encountered (and not replaced by stale entries) is returned.
+func (i *IrData) fetchMemberBatches(
+	ctx context.Context,
+	ids []int,
+	o *callOptions,
+	ret map[int]Member,
+) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, membersConcurrency)
+	)
+	for _, batch := range lo.Chunk(ids, MaxMembersPerRequest) {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			members, err := i.fetchMembers(ctx, batch, !o.noCache)
+			if err != nil && !o.noCache {
+				if stale, ok := i.staleMembers(ctx, batch, err, o); ok {
+					members, err = stale, nil
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			for j := range members {
+				ret[members[j].CustID] = members[j]
+			}
+		})
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// fetchMembers fetches a batch of members.
+// If store is set, each member is stored in the cache.
+func (i *IrData) fetchMembers(
+	ctx context.Context,
+	ids []int,
+	store bool,
+) ([]Member, error) {
+	v := url.Values{}
+	addInts(v, "cust_ids", ids)
+	uriRef := &url.URL{Path: membersEndpoint, RawQuery: v.Encode()}
+	key := flightKey(cache.NormalizeKey(uriRef.String()), false)
+	fetchCtx := context.WithoutCancel(ctx)
+	res, err := i.flights.do(ctx, key, func() (*fetchResult, error) {
+		return i.fetch(fetchCtx, uriRef)
+	})
+	if err != nil {
+		return nil, err
+	}
+	data := res.body
+	var resp MembersResponse
+	if err := i.decode(membersEndpoint, data, &resp); err != nil {
+		return nil, err
+	}
+	if !store {
+		return resp.Members, nil
+	}
+	var raw struct {
+		Members []json.RawMessage `json:"members"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	for j := range resp.Members {
+		i.cacheMember(resp.Members[j].CustID, raw.Members[j])
+	}
+	return resp.Members, nil
+}
+
+// cacheMember stores member as the response of a member/get call for custID
+func (i *IrData) cacheMember(custID int, member json.RawMessage) {
+	//nolint:tagliatelle // external definition
+	entry, err := json.Marshal(struct {
+		Success bool              `json:"success"`
+		CustIDs []int             `json:"cust_ids"`
+		Members []json.RawMessage `json:"members"`
+	}{true, []int{custID}, []json.RawMessage{member}})
+	if err != nil {
+		return
+	}
+	if err := i.cfg.cache.Set(memberCacheKey(custID), entry); err != nil {
+		log.Warn("failed to set cache", log.ErrorField(err))
+	}
+}
+
+func memberParams(custID int) url.Values {
+	v := url.Values{}
+	addInt(v, "cust_ids", custID)
+	return v
+}
+
+func memberCacheKey(custID int) string {
+	return requestURI(membersEndpoint, memberParams(custID))
+}
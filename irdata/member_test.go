@@ -0,0 +1,148 @@
+package irdata
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mpapenbr/irdata/cache"
+)
+
+// unknownMember is the first cust_id not known by the test server
+const unknownMember = 900000
+
+// memberServer answers member/get calls and records the batch sizes
+type memberServer struct {
+	mu      sync.Mutex
+	batches []int
+}
+
+func (s *memberServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ids := strings.Split(r.URL.Query().Get("cust_ids"), ",")
+	s.mu.Lock()
+	s.batches = append(s.batches, len(ids))
+	s.mu.Unlock()
+	resp := MembersResponse{Success: true}
+	for _, id := range ids {
+		custID, _ := strconv.Atoi(id)
+		resp.CustIDs = append(resp.CustIDs, custID)
+		if custID < unknownMember {
+			resp.Members = append(resp.Members,
+				Member{CustID: custID, DisplayName: "Driver " + id})
+		}
+	}
+	//nolint:errcheck // test server
+	json.NewEncoder(w).Encode(resp)
+}
+
+// newTestClient returns a client sending the data API requests to handler
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *IrData {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	i, err := NewIrData(append([]Option{
+		WithTokenProvider(func() (string, error) { return "token", nil }),
+	}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewIrData() error = %v", err)
+	}
+	if i.baseURL, err = url.Parse(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	return i
+}
+
+func TestMembersBatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		cached      []int // ids requested before
+		ids         []int
+		wantBatches []int
+		wantMembers int
+	}{
+		{"no ids", nil, nil, nil, 0},
+		{"single batch", nil, idRange(1, 10), []int{10}, 10},
+		{"full batch", nil, idRange(1, MaxMembersPerRequest), []int{50}, 50},
+		{"split", nil, idRange(1, 120), []int{20, 50, 50}, 120},
+		{"duplicates", nil, []int{1, 2, 1, 3, 2}, []int{3}, 3},
+		{"unknown ids", nil, []int{1, unknownMember, 2}, []int{3}, 2},
+		{"cached ids", idRange(1, 30), idRange(1, 60), []int{30}, 60},
+		{"all cached", idRange(1, 60), idRange(11, 20), nil, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &memberServer{}
+			i := newTestClient(t, srv, WithCache(cache.NewMemoryCache(1<<20, nil)))
+			ctx := context.Background()
+			if len(tt.cached) > 0 {
+				if _, err := i.Members(ctx, tt.cached); err != nil {
+					t.Fatalf("Members() error = %v", err)
+				}
+				srv.batches = nil
+			}
+			got, err := i.Members(ctx, tt.ids)
+			if err != nil {
+				t.Fatalf("Members() error = %v", err)
+			}
+			if len(got) != tt.wantMembers {
+				t.Errorf("got %d members, want %d", len(got), tt.wantMembers)
+			}
+			for _, id := range tt.ids {
+				if m, ok := got[id]; ok && m.CustID != id {
+					t.Errorf("member %d has cust_id %d", id, m.CustID)
+				}
+			}
+			slices.Sort(srv.batches)
+			if !slices.Equal(srv.batches, tt.wantBatches) {
+				t.Errorf("batches = %v, want %v", srv.batches, tt.wantBatches)
+			}
+		})
+	}
+}
+
+func TestMembersCacheOnly(t *testing.T) {
+	srv := &memberServer{}
+	i := newTestClient(t, srv, WithCache(cache.NewMemoryCache(1<<20, nil)))
+	ctx := context.Background()
+	if _, err := i.Members(ctx, idRange(1, 5)); err != nil {
+		t.Fatalf("Members() error = %v", err)
+	}
+	tests := []struct {
+		name    string
+		ids     []int
+		wantErr bool
+	}{
+		{"cached", idRange(1, 5), false},
+		{"not cached", idRange(4, 6), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := i.Members(ctx, tt.ids, CacheOnly())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Members() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(got) != len(tt.ids) {
+				t.Errorf("got %d members, want %d", len(got), len(tt.ids))
+			}
+		})
+	}
+	if len(srv.batches) != 1 {
+		t.Errorf("batches = %v, want a single request", srv.batches)
+	}
+}
+
+// idRange returns the ids from..to (inclusive)
+func idRange(from, to int) []int {
+	ret := make([]int, 0, to-from+1)
+	for id := from; id <= to; id++ {
+		ret = append(ret, id)
+	}
+	return ret
+}
@@ -0,0 +1,55 @@
+package irdata
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// helpers to build the query params of typed API calls.
+// Zero values are omitted so the API applies its own defaults.
+
+func addInt(v url.Values, key string, val int) {
+	if val != 0 {
+		v.Set(key, strconv.Itoa(val))
+	}
+}
+
+func addBool(v url.Values, key string, val bool) {
+	if val {
+		v.Set(key, "true")
+	}
+}
+
+func addString(v url.Values, key, val string) {
+	if val != "" {
+		v.Set(key, val)
+	}
+}
+
+// addTime adds val in the ISO-8601 format expected by the API (minute precision)
+func addTime(v url.Values, key string, val time.Time) {
+	if !val.IsZero() {
+		v.Set(key, val.UTC().Format("2006-01-02T15:04Z"))
+	}
+}
+
+func addInts[T ~int](v url.Values, key string, val []T) {
+	if len(val) == 0 {
+		return
+	}
+	s := make([]string, len(val))
+	for i := range val {
+		s[i] = strconv.Itoa(int(val[i]))
+	}
+	v.Set(key, strings.Join(s, ","))
+}
+
+// requestURI returns the uri of endpoint with params as used for cache keys
+func requestURI(endpoint string, params url.Values) string {
+	if len(params) == 0 {
+		return endpoint
+	}
+	return endpoint + "?" + params.Encode()
+}
@@ -0,0 +1,192 @@
+package irdata
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"sort"
+	"time"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	RaceGuideResponse struct {
+		Subscribed     bool               `json:"subscribed,omitempty"`
+		Success        bool               `json:"success,omitempty"`
+		BlockBeginTime IRTime             `json:"block_begin_time,omitempty"`
+		BlockEndTime   IRTime             `json:"block_end_time,omitempty"`
+		Sessions       []RaceGuideSession `json:"sessions,omitempty"`
+		RawJSON
+	}
+	RaceGuideSession struct {
+		SeasonID     int    `json:"season_id,omitempty"`
+		SeriesID     int    `json:"series_id,omitempty"`
+		RaceWeekNum  int    `json:"race_week_num,omitempty"`
+		SessionID    int    `json:"session_id,omitempty"`
+		StartTime    IRTime `json:"start_time,omitempty"`
+		EndTime      IRTime `json:"end_time,omitempty"`
+		EntryCount   int    `json:"entry_count,omitempty"`
+		SuperSession bool   `json:"super_session,omitempty"`
+	}
+
+	SpectatorSubsessionIDsResponse struct {
+		Success       bool        `json:"success,omitempty"`
+		EventTypes    []EventType `json:"event_types,omitempty"`
+		SubsessionIDs []int       `json:"subsession_ids,omitempty"`
+		RawJSON
+	}
+	SpectatorSubsessionsDetailResponse struct {
+		Success     bool                  `json:"success,omitempty"`
+		EventTypes  []EventType           `json:"event_types,omitempty"`
+		SeasonIDs   []int                 `json:"season_ids,omitempty"`
+		Subsessions []SpectatorSubsession `json:"subsessions,omitempty"`
+		RawJSON
+	}
+	SpectatorSubsession struct {
+		SubsessionID int       `json:"subsession_id,omitempty"`
+		SessionID    int       `json:"session_id,omitempty"`
+		SeasonID     int       `json:"season_id,omitempty"`
+		RaceWeekNum  int       `json:"race_week_num,omitempty"`
+		EventType    EventType `json:"event_type,omitempty"`
+		StartTime    IRTime    `json:"start_time,omitempty"`
+	}
+)
+
+type (
+	// RaceGuide combines the race guide sessions with season information
+	// so they can be filtered by series, category and license.
+	RaceGuide struct {
+		Entries []RaceGuideEntry
+	}
+	RaceGuideEntry struct {
+		RaceGuideSession
+		SeriesName   string
+		LicenseGroup int
+		Categories   []Category
+	}
+	// RaceGuideFilter restricts the race guide entries.
+	// Empty fields don't restrict the result.
+	RaceGuideFilter struct {
+		SeriesIDs     []int
+		Categories    []Category
+		LicenseGroups []int
+		// only entries starting before now+Within are returned (if > 0)
+		Within time.Duration
+	}
+)
+
+// RaceGuideCacheInterval is the interval in which iRacing updates the race guide
+const RaceGuideCacheInterval = time.Minute
+
+// RaceGuide returns the upcoming sessions starting at from.
+// If includeEndAfterFrom is true, sessions already running at from are included.
+func (i *IrData) RaceGuide(
+	ctx context.Context,
+	from time.Time,
+	includeEndAfterFrom bool,
+	opts ...CallOption,
+) (*RaceGuideResponse, error) {
+	v := url.Values{}
+	if !from.IsZero() {
+		v.Set("from", from.UTC().Format(time.RFC3339))
+	}
+	addBool(v, "include_end_after_from", includeEndAfterFrom)
+	return GetAs[*RaceGuideResponse](ctx, i, "/data/season/race_guide", v, opts...)
+}
+
+// SpectatorSubsessionIDs returns the ids of the subsessions that can be
+// spectated. If eventTypes is empty, all event types are included.
+func (i *IrData) SpectatorSubsessionIDs(
+	ctx context.Context,
+	eventTypes []EventType,
+	opts ...CallOption,
+) (*SpectatorSubsessionIDsResponse, error) {
+	v := url.Values{}
+	addInts(v, "event_types", eventTypes)
+	return GetAs[*SpectatorSubsessionIDsResponse](
+		ctx,
+		i,
+		"/data/season/spectator_subsessionids",
+		v,
+		opts...,
+	)
+}
+
+// SpectatorSubsessionsDetail is like SpectatorSubsessionIDs but returns
+// details of the subsessions. seasonIDs (optional) restricts the result.
+func (i *IrData) SpectatorSubsessionsDetail(
+	ctx context.Context,
+	eventTypes []EventType,
+	seasonIDs []int,
+	opts ...CallOption,
+) (*SpectatorSubsessionsDetailResponse, error) {
+	v := url.Values{}
+	addInts(v, "event_types", eventTypes)
+	addInts(v, "season_ids", seasonIDs)
+	return GetAs[*SpectatorSubsessionsDetailResponse](
+		ctx,
+		i,
+		"/data/season/spectator_subsessionids_detail",
+		v,
+		opts...,
+	)
+}
+
+// NewRaceGuide creates a race guide from the response. The seasons are used
+// to provide series name, license group and categories of the entries.
+// The entries are sorted by start time.
+func NewRaceGuide(resp *RaceGuideResponse, seasons []Season) *RaceGuide {
+	lookup := make(map[int]*Season, len(seasons))
+	for i := range seasons {
+		lookup[seasons[i].SeasonID] = &seasons[i]
+	}
+	g := &RaceGuide{Entries: make([]RaceGuideEntry, 0, len(resp.Sessions))}
+	for i := range resp.Sessions {
+		e := RaceGuideEntry{RaceGuideSession: resp.Sessions[i]}
+		if s, ok := lookup[e.SeasonID]; ok {
+			e.SeriesName = s.SeriesName
+			e.LicenseGroup = s.LicenseGroup
+			e.Categories = s.Categories()
+		}
+		g.Entries = append(g.Entries, e)
+	}
+	sort.SliceStable(g.Entries, func(a, b int) bool {
+		return g.Entries[a].StartTime.Before(g.Entries[b].StartTime.Time)
+	})
+	return g
+}
+
+// Filter returns the entries matching f. now is used for f.Within.
+func (g *RaceGuide) Filter(f *RaceGuideFilter, now time.Time) []RaceGuideEntry {
+	ret := make([]RaceGuideEntry, 0, len(g.Entries))
+	for i := range g.Entries {
+		if f.matches(&g.Entries[i], now) {
+			ret = append(ret, g.Entries[i])
+		}
+	}
+	return ret
+}
+
+func (f *RaceGuideFilter) matches(e *RaceGuideEntry, now time.Time) bool {
+	if len(f.SeriesIDs) > 0 && !slices.Contains(f.SeriesIDs, e.SeriesID) {
+		return false
+	}
+	if len(f.LicenseGroups) > 0 &&
+		!slices.Contains(f.LicenseGroups, e.LicenseGroup) {
+		return false
+	}
+	if len(f.Categories) > 0 && !slices.ContainsFunc(e.Categories,
+		func(c Category) bool { return slices.Contains(f.Categories, c) }) {
+		return false
+	}
+	if f.Within > 0 && e.StartTime.After(now.Add(f.Within)) {
+		return false
+	}
+	return true
+}
+
+// TimeToStart returns the duration until the session starts.
+// The result is negative if the session has already started.
+func (e *RaceGuideEntry) TimeToStart(now time.Time) time.Duration {
+	return e.StartTime.Sub(now)
+}
@@ -0,0 +1,59 @@
+package irdata
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mpapenbr/irdata/log"
+)
+
+// RateLimit returns the rate limit state reported by the last response
+func (i *IrData) RateLimit() RateLimit {
+	i.rlMutex.Lock()
+	defer i.rlMutex.Unlock()
+	return i.rl
+}
+
+// waitForRateLimit blocks until the next request is allowed.
+// Each call consumes one of the remaining requests of the current window.
+func (i *IrData) waitForRateLimit(ctx context.Context) error {
+	i.rlMutex.Lock()
+	if i.rl.Limit == 0 || i.rl.Remaining > 0 || time.Now().After(i.rl.Reset) {
+		if i.rl.Remaining > 0 {
+			i.rl.Remaining--
+		}
+		i.rlMutex.Unlock()
+		return nil
+	}
+	reset := i.rl.Reset
+	i.rlMutex.Unlock()
+
+	log.Info("rate limit reached, waiting for reset",
+		log.String("reset-time", reset.String()))
+	timer := time.NewTimer(time.Until(reset))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (i *IrData) updateRateLimit(h http.Header) {
+	limit, err := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	reset, _ := strconv.ParseFloat(h.Get("X-RateLimit-Reset"), 64)
+	i.rlMutex.Lock()
+	defer i.rlMutex.Unlock()
+	i.rl = RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(int64(reset), 0),
+	}
+}
@@ -0,0 +1,32 @@
+package irdata
+
+import "encoding/json"
+
+type (
+	// RawJSON is embedded in typed responses to retain the original payload.
+	// The typed models cover only a subset of the fields, the payload
+	// contains the complete document as delivered by the data API.
+	// It is only retained if the client was created with WithRawPayload.
+	RawJSON struct {
+		raw json.RawMessage
+	}
+	rawRetainer interface {
+		setRaw(data []byte)
+	}
+)
+
+// Raw returns the original payload of the response (nil if not retained)
+func (r *RawJSON) Raw() json.RawMessage {
+	return r.raw
+}
+
+func (r *RawJSON) setRaw(data []byte) {
+	r.raw = data
+}
+
+// WithRawPayload controls whether typed responses retain the original payload.
+func WithRawPayload(arg bool) Option {
+	return func(c *config) {
+		c.rawPayload = arg
+	}
+}
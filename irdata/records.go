@@ -0,0 +1,150 @@
+package irdata
+
+import (
+	"context"
+	"net/url"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	TimeAttackResult struct {
+		TaCompSeasonID int       `json:"ta_comp_season_id,omitempty"`
+		CustID         int       `json:"cust_id,omitempty"`
+		DisplayName    string    `json:"display_name,omitempty"`
+		CarID          int       `json:"car_id,omitempty"`
+		Track          TrackRef  `json:"track"`
+		BestLapTime    LapTime   `json:"best_lap_time,omitempty"`
+		Position       int       `json:"position,omitempty"`
+		Points         int       `json:"points,omitempty"`
+		Date           IRDate    `json:"date,omitempty"`
+		EventType      EventType `json:"event_type,omitempty"`
+	}
+
+	// WorldRecord is the best lap of a driver for a car/track combination.
+	WorldRecord struct {
+		CarID           int     `json:"car_id,omitempty"`
+		TrackID         int     `json:"track_id,omitempty"`
+		CustID          int     `json:"cust_id,omitempty"`
+		DisplayName     string  `json:"display_name,omitempty"`
+		Region          string  `json:"region,omitempty"`
+		ClubID          int     `json:"club_id,omitempty"`
+		ClubName        string  `json:"club_name,omitempty"`
+		CountryCode     string  `json:"country_code,omitempty"`
+		SeasonYear      int     `json:"season_year,omitempty"`
+		SeasonQuarter   int     `json:"season_quarter,omitempty"`
+		PracticeLapTime LapTime `json:"practice_lap_time,omitempty"`
+		QualifyLapTime  LapTime `json:"qualify_lap_time,omitempty"`
+		TTLapTime       LapTime `json:"tt_lap_time,omitempty"`
+		RaceLapTime     LapTime `json:"race_lap_time,omitempty"`
+	}
+)
+
+type (
+	// RecordsMatrix contains the best lap for each car/track combination
+	RecordsMatrix struct {
+		SeasonYear int           `json:"seasonYear,omitempty"`
+		CarIDs     []int         `json:"carIds"`
+		TrackIDs   []int         `json:"trackIds"`
+		Cells      []RecordsCell `json:"cells"`
+	}
+	RecordsCell struct {
+		CarID       int     `json:"carId"`
+		TrackID     int     `json:"trackId"`
+		CustID      int     `json:"custId,omitempty"`
+		DisplayName string  `json:"displayName,omitempty"`
+		LapTime     LapTime `json:"lapTime,omitempty"`
+	}
+)
+
+// TimeAttackMemberSeasonResults returns the results of the authenticated
+// customer in a time attack competition season.
+func (i *IrData) TimeAttackMemberSeasonResults(
+	ctx context.Context,
+	taCompSeasonID int,
+	opts ...CallOption,
+) ([]TimeAttackResult, error) {
+	v := url.Values{}
+	addInt(v, "ta_comp_season_id", taCompSeasonID)
+	return GetAs[[]TimeAttackResult](
+		ctx,
+		i,
+		"/data/time_attack/member_season_results",
+		v,
+		opts...,
+	)
+}
+
+// WorldRecords returns the world records of a car on a track.
+// seasonYear and seasonQuarter are optional filters (0 means no filter).
+// All chunks of the result are fetched.
+func (i *IrData) WorldRecords(
+	ctx context.Context,
+	carID, trackID, seasonYear, seasonQuarter int,
+	opts ...CallOption,
+) ([]WorldRecord, error) {
+	v := url.Values{}
+	addInt(v, "car_id", carID)
+	addInt(v, "track_id", trackID)
+	addInt(v, "season_year", seasonYear)
+	addInt(v, "season_quarter", seasonQuarter)
+	return getChunked[WorldRecord](ctx, i, "/data/stats/world_records", v, opts...)
+}
+
+// BestLapTime returns the best of the recorded lap times (0 if none).
+func (r *WorldRecord) BestLapTime() LapTime {
+	best := LapTime(0)
+	for _, t := range []LapTime{
+		r.PracticeLapTime, r.QualifyLapTime, r.TTLapTime, r.RaceLapTime,
+	} {
+		if t.Valid() && (best == 0 || t < best) {
+			best = t
+		}
+	}
+	return best
+}
+
+// WorldRecordsMatrix collects the best lap of each car/track combination
+// in seasonYear. Combinations without records are included as empty cells.
+func (i *IrData) WorldRecordsMatrix(
+	ctx context.Context,
+	seasonYear int,
+	carIDs, trackIDs []int,
+	opts ...CallOption,
+) (*RecordsMatrix, error) {
+	m := &RecordsMatrix{
+		SeasonYear: seasonYear,
+		CarIDs:     carIDs,
+		TrackIDs:   trackIDs,
+		Cells:      make([]RecordsCell, 0, len(carIDs)*len(trackIDs)),
+	}
+	for _, carID := range carIDs {
+		for _, trackID := range trackIDs {
+			records, err := i.WorldRecords(ctx, carID, trackID, seasonYear, 0, opts...)
+			if err != nil {
+				return nil, err
+			}
+			cell := RecordsCell{CarID: carID, TrackID: trackID}
+			for j := range records {
+				r := &records[j]
+				if t := r.BestLapTime(); t.Valid() &&
+					(cell.LapTime == 0 || t < cell.LapTime) {
+					cell.LapTime = t
+					cell.CustID = r.CustID
+					cell.DisplayName = r.DisplayName
+				}
+			}
+			m.Cells = append(m.Cells, cell)
+		}
+	}
+	return m, nil
+}
+
+// CellIndex returns the cells indexed by car and track id ({carID, trackID}).
+func (m *RecordsMatrix) CellIndex() map[[2]int]*RecordsCell {
+	ret := make(map[[2]int]*RecordsCell, len(m.Cells))
+	for j := range m.Cells {
+		c := &m.Cells[j]
+		ret[[2]int{c.CarID, c.TrackID}] = c
+	}
+	return ret
+}
@@ -0,0 +1,132 @@
+package irdata
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/mpapenbr/irdata/cache"
+)
+
+type (
+	// Source tells where the data of a Response came from
+	Source int
+
+	// Response contains the data of a call together with its metadata
+	Response struct {
+		Body     []byte
+		Source   Source
+		Endpoint string // path of the requested uri
+		// time the data was fetched from the API (zero if unknown)
+		FetchedAt time.Time
+		// expiry of the cache entry (zero: no expiry or not cached)
+		ExpiresAt time.Time
+		// HTTP status of the last response (0 if served from the cache)
+		Status    int
+		RateLimit RateLimit // rate limit state after the call
+		Link      string    // S3 link the data was downloaded from (if any)
+		Duration  time.Duration
+		// error of the failed request if an outdated cache entry was
+		// served instead (SourceStale)
+		Err error
+	}
+)
+
+const (
+	// SourceNetwork: the data was fetched from the API
+	SourceNetwork Source = iota
+	// SourceCache: the data was served from the cache
+	SourceCache
+	// SourceStale: the data was served from an expired or outdated cache
+	// entry because the request failed or network access is disabled
+	SourceStale
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceNetwork:
+		return "network"
+	case SourceCache:
+		return "cache"
+	case SourceStale:
+		return "stale"
+	default:
+		return fmt.Sprintf("source(%d)", int(s))
+	}
+}
+
+// Do is like Get but returns the data together with its metadata.
+// The returned Response is never nil. On errors it contains the metadata
+// known so far, for example the HTTP status of a failed request.
+func (i *IrData) Do(
+	ctx context.Context,
+	uri string,
+	opts ...CallOption,
+) (*Response, error) {
+	start := time.Now()
+	resp, err := i.do(ctx, uri, opts)
+	resp.RateLimit = i.RateLimit()
+	resp.Duration = time.Since(start)
+	return resp, err
+}
+
+func (i *IrData) do(
+	ctx context.Context,
+	uri string,
+	opts []CallOption,
+) (*Response, error) {
+	resp := &Response{}
+	uriRef, err := url.Parse(uri)
+	if err != nil {
+		return resp, fmt.Errorf("failed to parse URI: %w", err)
+	}
+	resp.Endpoint = uriRef.Path
+	key := cache.NormalizeKey(uri)
+	o := i.resolveCallOptions(opts)
+	useCache := !liveEndpoints[uriRef.Path] && !o.noCache
+	var entry *cache.Entry
+	var cached bool
+	// calls restricted to the cache use it even if a refresh is requested.
+	// Otherwise a refresh looks the entry up as stale candidate only.
+	if useCache && (!o.refresh || o.cacheOnly || i.cfg.serveStale) {
+		entry, cached = i.lookup(uri)
+		if cached && (!o.refresh || o.cacheOnly) && o.usable(entry, time.Now()) {
+			resp.setEntry(entry, SourceCache)
+			return resp, nil
+		}
+	}
+	res, err := i.fetchShared(ctx, key, uriRef, o, useCache)
+	if err != nil {
+		if res != nil {
+			resp.Status = res.status
+			resp.Link = res.link
+		}
+		if cached && i.fallbackStale(ctx, key, err, o) {
+			resp.setEntry(entry, SourceStale)
+			resp.Err = err
+			return resp, nil
+		}
+		return resp, err
+	}
+	resp.setFetchResult(res)
+	if tp, ok := i.cfg.cache.(cache.TTLProvider); ok && useCache && tp.TTL() > 0 {
+		resp.ExpiresAt = res.fetchedAt.Add(tp.TTL())
+	}
+	return resp, nil
+}
+
+func (r *Response) setEntry(entry *cache.Entry, source Source) {
+	r.Body = entry.Value
+	r.Source = source
+	r.FetchedAt = entry.FetchedAt
+	r.ExpiresAt = entry.ExpiresAt
+}
+
+func (r *Response) setFetchResult(res *fetchResult) {
+	r.Body = res.body
+	r.Source = SourceNetwork
+	r.FetchedAt = res.fetchedAt
+	r.Status = res.status
+	r.Link = res.link
+}
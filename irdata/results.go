@@ -0,0 +1,135 @@
+package irdata
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	// SearchResult is a row of results/search_series and results/search_hosted.
+	// Depending on the search params a row describes the session or the
+	// result of a driver/team in the session.
+	SearchResult struct {
+		SessionID             int       `json:"session_id,omitempty"`
+		SubsessionID          int       `json:"subsession_id,omitempty"`
+		StartTime             IRTime    `json:"start_time,omitempty"`
+		EndTime               IRTime    `json:"end_time,omitempty"`
+		LicenseCategoryID     int       `json:"license_category_id,omitempty"`
+		LicenseCategory       string    `json:"license_category,omitempty"`
+		NumDrivers            int       `json:"num_drivers,omitempty"`
+		OfficialSession       bool      `json:"official_session,omitempty"`
+		EventType             EventType `json:"event_type,omitempty"`
+		EventTypeName         string    `json:"event_type_name,omitempty"`
+		SeasonID              int       `json:"season_id,omitempty"`
+		SeasonYear            int       `json:"season_year,omitempty"`
+		SeasonQuarter         int       `json:"season_quarter,omitempty"`
+		SeriesID              int       `json:"series_id,omitempty"`
+		SeriesName            string    `json:"series_name,omitempty"`
+		RaceWeekNum           int       `json:"race_week_num,omitempty"`
+		SessionName           string    `json:"session_name,omitempty"`
+		LeagueID              int       `json:"league_id,omitempty"`
+		LeagueSeasonID        int       `json:"league_season_id,omitempty"`
+		Host                  MemberRef `json:"host"`
+		Track                 TrackRef  `json:"track"`
+		EventStrengthOfField  int       `json:"event_strength_of_field,omitempty"`
+		EventBestLapTime      LapTime   `json:"event_best_lap_time,omitempty"`
+		WinnerName            string    `json:"winner_name,omitempty"`
+		CustID                int       `json:"cust_id,omitempty"`
+		TeamID                int       `json:"team_id,omitempty"`
+		DisplayName           string    `json:"display_name,omitempty"`
+		CarID                 int       `json:"car_id,omitempty"`
+		CarName               string    `json:"car_name,omitempty"`
+		CarClassID            int       `json:"car_class_id,omitempty"`
+		CarClassName          string    `json:"car_class_name,omitempty"`
+		StartingPosition      int       `json:"starting_position,omitempty"`
+		FinishPosition        int       `json:"finish_position,omitempty"`
+		FinishPositionInClass int       `json:"finish_position_in_class,omitempty"`
+	}
+)
+
+type (
+	// ResultsSearchParams holds the params of results/search_series and
+	// results/search_hosted. Zero values are not sent to the API.
+	// The API requires either a time range or season year and quarter.
+	// A time range may not exceed 90 days.
+	ResultsSearchParams struct {
+		StartRangeBegin  time.Time
+		StartRangeEnd    time.Time
+		FinishRangeBegin time.Time
+		FinishRangeEnd   time.Time
+		CustID           int
+		TeamID           int
+		CategoryIDs      []Category
+		// search_series only
+		SeasonYear    int
+		SeasonQuarter int
+		SeriesID      int
+		OfficialOnly  bool
+		EventTypes    []EventType
+		// search_hosted only
+		HostCustID     int
+		SessionName    string
+		LeagueID       int
+		LeagueSeasonID int
+		CarID          int
+		TrackID        int
+	}
+)
+
+// MaxSearchRange is the maximum time range of a results search
+const MaxSearchRange = 90 * 24 * time.Hour
+
+func (p *ResultsSearchParams) values() url.Values {
+	v := url.Values{}
+	addTime(v, "start_range_begin", p.StartRangeBegin)
+	addTime(v, "start_range_end", p.StartRangeEnd)
+	addTime(v, "finish_range_begin", p.FinishRangeBegin)
+	addTime(v, "finish_range_end", p.FinishRangeEnd)
+	addInt(v, "cust_id", p.CustID)
+	addInt(v, "team_id", p.TeamID)
+	addInts(v, "category_ids", p.CategoryIDs)
+	addInt(v, "season_year", p.SeasonYear)
+	addInt(v, "season_quarter", p.SeasonQuarter)
+	addInt(v, "series_id", p.SeriesID)
+	addBool(v, "official_only", p.OfficialOnly)
+	addInts(v, "event_types", p.EventTypes)
+	addInt(v, "host_cust_id", p.HostCustID)
+	addString(v, "session_name", p.SessionName)
+	addInt(v, "league_id", p.LeagueID)
+	addInt(v, "league_season_id", p.LeagueSeasonID)
+	addInt(v, "car_id", p.CarID)
+	addInt(v, "track_id", p.TrackID)
+	return v
+}
+
+// SearchSeriesResults searches official series results.
+// All chunks of the result are fetched.
+func (i *IrData) SearchSeriesResults(
+	ctx context.Context,
+	p *ResultsSearchParams,
+	opts ...CallOption,
+) ([]SearchResult, error) {
+	return getChunked[SearchResult](
+		ctx,
+		i,
+		"/data/results/search_series",
+		p.values(),
+		opts...)
+}
+
+// SearchHostedResults searches hosted and league session results.
+// All chunks of the result are fetched.
+func (i *IrData) SearchHostedResults(
+	ctx context.Context,
+	p *ResultsSearchParams,
+	opts ...CallOption,
+) ([]SearchResult, error) {
+	return getChunked[SearchResult](
+		ctx,
+		i,
+		"/data/results/search_hosted",
+		p.values(),
+		opts...)
+}
@@ -1,13 +1,30 @@
 package irdata
 
+import (
+	"context"
+	"net/url"
+)
+
 //nolint:tagliatelle // external definition
 type (
 	ScheduleResponse struct {
 		Schedules []Schedule `json:"schedules,omitempty"`
+		RawJSON
 	}
 	Schedule struct {
-		SeasonID     int  `json:"season_id,omitempty"`
-		QualAttached bool `json:"qual_attached,omitempty"`
-		RaceWeekNum  int  `json:"race_week_num,omitempty"`
+		SeasonID     int      `json:"season_id,omitempty"`
+		QualAttached bool     `json:"qual_attached,omitempty"`
+		RaceWeekNum  int      `json:"race_week_num,omitempty"`
+		Track        TrackRef `json:"track"`
 	}
 )
+
+func (i *IrData) SeasonSchedule(
+	ctx context.Context,
+	seasonID int,
+	opts ...CallOption,
+) (*ScheduleResponse, error) {
+	v := url.Values{}
+	addInt(v, "season_id", seasonID)
+	return GetAs[*ScheduleResponse](ctx, i, "/data/series/season_schedule", v, opts...)
+}
@@ -0,0 +1,72 @@
+package irdata
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownEndpoint is returned by CheckSchema for endpoints without a typed model
+var ErrUnknownEndpoint = errors.New("no typed model for endpoint")
+
+// schemaTargets maps endpoints to the typed model of their response.
+// Chunked and CSV endpoints are not included, their responses
+// only contain the chunk or link information.
+//
+//nolint:lll // table is easier to read this way
+var schemaTargets = map[string]func() any{
+	"/data/car/assets":                            schemaOf[map[int]CarAsset],
+	"/data/car/get":                               schemaOf[[]Car],
+	"/data/carclass/get":                          schemaOf[[]CarClass],
+	"/data/constants/categories":                  schemaOf[[]Constant],
+	"/data/constants/divisions":                   schemaOf[[]Constant],
+	"/data/constants/event_types":                 schemaOf[[]Constant],
+	"/data/hosted/combined_sessions":              schemaOf[HostedSessionsResponse],
+	"/data/hosted/sessions":                       schemaOf[HostedSessionsResponse],
+	"/data/league/cust_league_sessions":           schemaOf[CustLeagueSessionsResponse],
+	"/data/league/directory":                      schemaOf[LeagueDirectoryResponse],
+	"/data/league/get":                            schemaOf[League],
+	"/data/league/get_points_systems":             schemaOf[LeaguePointsSystemsResponse],
+	"/data/league/membership":                     schemaOf[[]LeagueMembership],
+	"/data/league/roster":                         schemaOf[LeagueRosterResponse],
+	"/data/league/season_sessions":                schemaOf[LeagueSeasonSessionsResponse],
+	"/data/league/season_standings":               schemaOf[LeagueSeasonStandingsResponse],
+	"/data/league/seasons":                        schemaOf[LeagueSeasonsResponse],
+	"/data/lookup/countries":                      schemaOf[[]Country],
+	"/data/lookup/drivers":                        schemaOf[[]DriverSearchResult],
+	"/data/lookup/flairs":                         schemaOf[FlairsResponse],
+	"/data/lookup/get":                            schemaOf[[]LookupResponse],
+	"/data/lookup/licenses":                       schemaOf[[]LicenseGroup],
+	"/data/season/race_guide":                     schemaOf[RaceGuideResponse],
+	"/data/season/spectator_subsessionids":        schemaOf[SpectatorSubsessionIDsResponse],
+	"/data/season/spectator_subsessionids_detail": schemaOf[SpectatorSubsessionsDetailResponse],
+	"/data/member/get":                            schemaOf[MembersResponse],
+	"/data/results/get":                           schemaOf[SubsessionResult],
+	"/data/results/lap_chart_data":                schemaOf[LapChartDataResponse],
+	"/data/results/lap_data":                      schemaOf[LapDataResponse],
+	"/data/results/season_results":                schemaOf[SeasonResultsResponse],
+	"/data/series/assets":                         schemaOf[map[int]SeriesAsset],
+	"/data/series/get":                            schemaOf[[]Series],
+	"/data/series/past_seasons":                   schemaOf[PastSeasonsResponse],
+	"/data/series/season_list":                    schemaOf[SeasonList],
+	"/data/series/season_schedule":                schemaOf[ScheduleResponse],
+	"/data/series/seasons":                        schemaOf[[]Season],
+	"/data/series/stats_series":                   schemaOf[[]SeriesWithSeasons],
+	"/data/team/get":                              schemaOf[Team],
+	"/data/time_attack/member_season_results":     schemaOf[[]TimeAttackResult],
+	"/data/track/assets":                          schemaOf[map[int]TrackAsset],
+	"/data/track/get":                             schemaOf[[]Track],
+}
+
+func schemaOf[T any]() any {
+	return new(T)
+}
+
+// CheckSchema decodes data (a response of endpoint) into its typed model and
+// returns the fields not covered by the model.
+func CheckSchema(endpoint string, data []byte) ([]string, error) {
+	target, ok := schemaTargets[endpoint]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEndpoint, endpoint)
+	}
+	return UnknownFields(data, target())
+}
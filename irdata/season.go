@@ -1,17 +1,30 @@
 package irdata
 
+import (
+	"context"
+	"net/url"
+)
+
 //nolint:tagliatelle // external definition
 type (
 	SeasonList struct {
 		Seasons []Season `json:"seasons,omitempty"`
+		RawJSON
 	}
 	Season struct {
 		SeasonID        int               `json:"season_id,omitempty"`
+		SeriesID        int               `json:"series_id,omitempty"`
 		SeasonYear      int               `json:"season_year,omitempty"`
 		SeasonQuarter   int               `json:"season_quarter,omitempty"`
 		SeasonName      string            `json:"season_name,omitempty"`
 		SeasonShortName string            `json:"season_short_name,omitempty"`
+		SeriesName      string            `json:"series_name,omitempty"`
+		LicenseGroup    int               `json:"license_group,omitempty"`
+		Official        bool              `json:"official,omitempty"`
+		Active          bool              `json:"active,omitempty"`
 		TrackTypes      []SeasonTrackType `json:"track_types,omitempty"`
+		// only provided by series/seasons
+		Schedules []Schedule `json:"schedules,omitempty"`
 	}
 	SeasonTrackType struct {
 		TrackType string `json:"track_type,omitempty"`
@@ -20,3 +33,26 @@ type (
 		CarType string `json:"car_type,omitempty"`
 	}
 )
+
+// Categories returns the categories of the season derived from its track types.
+func (s *Season) Categories() []Category {
+	ret := make([]Category, 0, len(s.TrackTypes))
+	for i := range s.TrackTypes {
+		if c, ok := CategoryFromTrackType(s.TrackTypes[i].TrackType); ok {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// SeasonList returns the seasons of the given year and quarter.
+func (i *IrData) SeasonList(
+	ctx context.Context,
+	year, quarter int,
+	opts ...CallOption,
+) (*SeasonList, error) {
+	v := url.Values{}
+	addInt(v, "season_year", year)
+	addInt(v, "season_quarter", quarter)
+	return GetAs[*SeasonList](ctx, i, "/data/series/season_list", v, opts...)
+}
@@ -1 +1,123 @@
 package irdata
+
+import (
+	"context"
+	"net/url"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	Series struct {
+		SeriesID        int      `json:"series_id,omitempty"`
+		SeriesName      string   `json:"series_name,omitempty"`
+		SeriesShortName string   `json:"series_short_name,omitempty"`
+		Category        string   `json:"category,omitempty"`
+		CategoryID      Category `json:"category_id,omitempty"`
+		Eligible        bool     `json:"eligible,omitempty"`
+		MinStarters     int      `json:"min_starters,omitempty"`
+		MaxStarters     int      `json:"max_starters,omitempty"`
+		ForumURL        string   `json:"forum_url,omitempty"`
+	}
+	SeriesAsset struct {
+		SeriesID   int    `json:"series_id,omitempty"`
+		LargeImage string `json:"large_image,omitempty"`
+		SmallImage string `json:"small_image,omitempty"`
+		Logo       string `json:"logo,omitempty"`
+		SeriesCopy string `json:"series_copy,omitempty"`
+	}
+
+	// SeriesWithSeasons is returned by series/past_seasons and series/stats_series
+	SeriesWithSeasons struct {
+		SeriesID        int          `json:"series_id,omitempty"`
+		SeriesName      string       `json:"series_name,omitempty"`
+		SeriesShortName string       `json:"series_short_name,omitempty"`
+		Category        string       `json:"category,omitempty"`
+		CategoryID      Category     `json:"category_id,omitempty"`
+		Active          bool         `json:"active,omitempty"`
+		Official        bool         `json:"official,omitempty"`
+		FixedSetup      bool         `json:"fixed_setup,omitempty"`
+		LicenseGroup    int          `json:"license_group,omitempty"`
+		Logo            string       `json:"logo,omitempty"`
+		Seasons         []PastSeason `json:"seasons,omitempty"`
+	}
+	PastSeason struct {
+		SeasonID        int                  `json:"season_id,omitempty"`
+		SeriesID        int                  `json:"series_id,omitempty"`
+		SeasonName      string               `json:"season_name,omitempty"`
+		SeasonShortName string               `json:"season_short_name,omitempty"`
+		SeasonYear      int                  `json:"season_year,omitempty"`
+		SeasonQuarter   int                  `json:"season_quarter,omitempty"`
+		Active          bool                 `json:"active,omitempty"`
+		Official        bool                 `json:"official,omitempty"`
+		DriverChanges   bool                 `json:"driver_changes,omitempty"`
+		FixedSetup      bool                 `json:"fixed_setup,omitempty"`
+		LicenseGroup    int                  `json:"license_group,omitempty"`
+		CarClasses      []PastSeasonCarClass `json:"car_classes,omitempty"`
+		RaceWeeks       []PastSeasonRaceWeek `json:"race_weeks,omitempty"`
+	}
+	PastSeasonCarClass struct {
+		CarClassID    int    `json:"car_class_id,omitempty"`
+		Name          string `json:"name,omitempty"`
+		ShortName     string `json:"short_name,omitempty"`
+		RelativeSpeed int    `json:"relative_speed,omitempty"`
+	}
+	PastSeasonRaceWeek struct {
+		SeasonID    int      `json:"season_id,omitempty"`
+		RaceWeekNum int      `json:"race_week_num,omitempty"`
+		Track       TrackRef `json:"track"`
+	}
+	PastSeasonsResponse struct {
+		Success  bool              `json:"success,omitempty"`
+		SeriesID int               `json:"series_id,omitempty"`
+		Series   SeriesWithSeasons `json:"series"`
+		RawJSON
+	}
+)
+
+// LogoURL returns the absolute URL of the series logo.
+func (a *SeriesAsset) LogoURL() string {
+	return assetURL("img/logos/series", a.Logo)
+}
+
+func (i *IrData) Series(ctx context.Context, opts ...CallOption) ([]Series, error) {
+	return GetAs[[]Series](ctx, i, "/data/series/get", nil, opts...)
+}
+
+// SeriesAssets returns the series assets indexed by series id.
+func (i *IrData) SeriesAssets(
+	ctx context.Context,
+	opts ...CallOption,
+) (map[int]SeriesAsset, error) {
+	return GetAs[map[int]SeriesAsset](ctx, i, "/data/series/assets", nil, opts...)
+}
+
+// SeriesSeasons returns the current seasons including their schedules.
+// If includeSeries is true, the series data is included in the seasons.
+func (i *IrData) SeriesSeasons(
+	ctx context.Context,
+	includeSeries bool,
+	opts ...CallOption,
+) ([]Season, error) {
+	v := url.Values{}
+	addBool(v, "include_series", includeSeries)
+	return GetAs[[]Season](ctx, i, "/data/series/seasons", v, opts...)
+}
+
+// SeriesPastSeasons returns all seasons of a series.
+func (i *IrData) SeriesPastSeasons(
+	ctx context.Context,
+	seriesID int,
+	opts ...CallOption,
+) (*PastSeasonsResponse, error) {
+	v := url.Values{}
+	addInt(v, "series_id", seriesID)
+	return GetAs[*PastSeasonsResponse](ctx, i, "/data/series/past_seasons", v, opts...)
+}
+
+// SeriesStats returns all series with all their seasons.
+func (i *IrData) SeriesStats(
+	ctx context.Context,
+	opts ...CallOption,
+) ([]SeriesWithSeasons, error) {
+	return GetAs[[]SeriesWithSeasons](ctx, i, "/data/series/stats_series", nil, opts...)
+}
@@ -0,0 +1,153 @@
+package irdata
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/mpapenbr/irdata/cache"
+	"github.com/mpapenbr/irdata/log"
+)
+
+type (
+	// staleSet collects the uris served from expired cache entries
+	// together with the error of the upstream request
+	staleSet struct {
+		mu   sync.Mutex
+		uris map[string]error
+	}
+)
+
+// ErrOffline is returned in offline mode if network access is required.
+var ErrOffline = errors.New("network access disabled (offline mode)")
+
+// WithOffline disables all network access. Data is served from the cache
+// only, including expired entries (see CacheOnly). Requests not found in the
+// cache fail with ErrNotCached.
+func WithOffline(arg bool) Option {
+	return func(c *config) {
+		c.offline = arg
+	}
+}
+
+// WithServeStale enables serving expired cache entries if the upstream
+// request fails (network errors, 5xx, maintenance). Such responses are
+// reported by IrData.Stale. Requires a cache implementing cache.EntryReader
+// (otherwise expired entries are not returned by the cache at all).
+func WithServeStale(arg bool) Option {
+	return func(c *config) {
+		c.serveStale = arg
+	}
+}
+
+// Stale returns the uris whose latest response was served from an expired
+// cache entry together with the error of the upstream request.
+// A uri is removed once it is fetched successfully again.
+func (i *IrData) Stale() map[string]error {
+	i.stale.mu.Lock()
+	defer i.stale.mu.Unlock()
+	return maps.Clone(i.stale.uris)
+}
+
+// lookup returns the cache entry of uri (even if expired) stored under the
+// normalized key of uri (see cache.NormalizeKey). Entries stored under the
+// raw uri by older versions are moved to the normalized key.
+// The metadata is only set if the cache implements cache.EntryReader.
+func (i *IrData) lookup(uri string) (*cache.Entry, bool) {
+	key := cache.NormalizeKey(uri)
+	entry, ok := i.getEntry(key)
+	if ok || key == uri {
+		return entry, ok
+	}
+	if entry, ok = i.getEntry(uri); ok {
+		i.moveEntry(uri, key, entry)
+	}
+	return entry, ok
+}
+
+func (i *IrData) getEntry(key string) (*cache.Entry, bool) {
+	if er, ok := i.cfg.cache.(cache.EntryReader); ok {
+		return er.GetEntry(key)
+	}
+	value, ok := i.cfg.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return &cache.Entry{Value: value}, true
+}
+
+// moveEntry stores entry under key and removes it from the legacy key.
+// The fetch time is kept if the cache implements cache.EntryWriter.
+func (i *IrData) moveEntry(legacy, key string, entry *cache.Entry) {
+	var err error
+	if ew, ok := i.cfg.cache.(cache.EntryWriter); ok {
+		err = ew.SetEntry(key, entry)
+	} else {
+		err = i.cfg.cache.Set(key, entry.Value)
+	}
+	if err == nil {
+		err = i.cfg.cache.Delete(legacy)
+	}
+	if err != nil {
+		log.Warn("failed to move cache entry",
+			log.String("uri", legacy), log.ErrorField(err))
+		return
+	}
+	log.Debug("moved cache entry to normalized key",
+		log.String("uri", legacy), log.String("key", key))
+}
+
+// fallbackStale reports whether the cached (expired or outdated) entry of uri
+// may be served after the request failed with err. If so, uri is recorded
+// as stale.
+func (i *IrData) fallbackStale(
+	ctx context.Context,
+	uri string,
+	err error,
+	o *callOptions,
+) bool {
+	if !o.cacheOnly &&
+		(!i.cfg.serveStale || ctx.Err() != nil || !upstreamFailure(err)) {
+		return false
+	}
+	if o.cacheOnly {
+		log.Debug("serving expired cache entry", log.String("uri", uri))
+	} else {
+		log.Warn("serving stale cache entry",
+			log.String("uri", uri), log.ErrorField(err))
+	}
+	i.stale.add(uri, err)
+	return true
+}
+
+// upstreamFailure reports whether err is a network error or a server error
+// of the data API or S3 (5xx, 503 during maintenance). Other errors (for
+// example 4xx or decoding errors) are not fixed by serving stale data.
+func upstreamFailure(err error) bool {
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.StatusCode >= http.StatusInternalServerError
+	}
+	var ne net.Error
+	var ue *url.Error
+	return errors.As(err, &ne) || errors.As(err, &ue)
+}
+
+func (s *staleSet) add(uri string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.uris == nil {
+		s.uris = map[string]error{}
+	}
+	s.uris[uri] = err
+}
+
+func (s *staleSet) remove(uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.uris, uri)
+}
@@ -0,0 +1,213 @@
+package irdata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	badger "github.com/dgraph-io/badger/v4"
+
+	"github.com/mpapenbr/irdata/cache"
+)
+
+func TestUpstreamFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"maintenance", &StatusError{StatusCode: 503}, true},
+		{"server error", fmt.Errorf("wrapped: %w", &StatusError{StatusCode: 500}), true},
+		{"s3 server error", &StatusError{StatusCode: 502, S3: true}, true},
+		{"unauthorized", &StatusError{StatusCode: 401}, false},
+		{"not found", &StatusError{StatusCode: 404}, false},
+		{"rate limited", &StatusError{StatusCode: 429}, false},
+		{"url error", &url.Error{Op: "Get", URL: "/data", Err: errors.New("eof")}, true},
+		{"net error", &net.DNSError{Err: "no such host", IsTimeout: true}, true},
+		{"decode error", errors.New("failed to decode"), false},
+		{"link expired", ErrLinkExpired, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := upstreamFailure(tt.err); got != tt.want {
+				t.Errorf("upstreamFailure(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFallbackStale(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	serverErr := &StatusError{StatusCode: 503}
+	tests := []struct {
+		name       string
+		serveStale bool
+		cacheOnly  bool
+		ctx        context.Context
+		err        error
+		want       bool
+	}{
+		{"disabled", false, false, context.Background(), serverErr, false},
+		{"server error", true, false, context.Background(), serverErr, true},
+		{
+			"client error", true, false, context.Background(),
+			&StatusError{StatusCode: 400}, false,
+		},
+		{"canceled", true, false, canceled, serverErr, false},
+		{"cache only", false, true, context.Background(), ErrOffline, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, err := NewIrData(WithServeStale(tt.serveStale))
+			if err != nil {
+				t.Fatal(err)
+			}
+			o := &callOptions{cacheOnly: tt.cacheOnly}
+			if got := i.fallbackStale(tt.ctx, "/data/car/get", tt.err, o); got != tt.want {
+				t.Errorf("fallbackStale() = %v, want %v", got, tt.want)
+			}
+			if _, stale := i.Stale()["/data/car/get"]; stale != tt.want {
+				t.Errorf("recorded as stale = %v, want %v", stale, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupLegacyKey(t *testing.T) {
+	// stored by older versions under the raw uri (params not sorted)
+	raw := "/data/results/season_results?season_id=5200&race_week_num=3"
+	body := `{"success":true}`
+	tests := []struct {
+		name   string
+		memory bool // memory tier in front of badger
+	}{
+		{"badger", false},
+		{"memory tier", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := badger.Open(
+				badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { db.Close() })
+			if err = db.Update(func(txn *badger.Txn) error {
+				return txn.Set([]byte(raw), []byte(body))
+			}); err != nil {
+				t.Fatal(err)
+			}
+			c, _ := cache.NewBadgerCache(db)
+			if tt.memory {
+				c = cache.NewMemoryCache(1<<20, c)
+			}
+			i := newTestClient(t, http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					t.Error("unexpected request")
+					w.WriteHeader(http.StatusNotFound)
+				}), WithCache(c))
+
+			resp, err := i.Do(context.Background(), raw)
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			if resp.Source != SourceCache || string(resp.Body) != body {
+				t.Errorf("got %s from %v, want %s from cache",
+					resp.Body, resp.Source, body)
+			}
+			er, _ := c.(cache.EntryReader)
+			entry, ok := er.GetEntry(cache.NormalizeKey(raw))
+			if !ok || string(entry.Value) != body {
+				t.Fatalf("entry of normalized key = %v, %v", entry, ok)
+			}
+			if !entry.FetchedAt.IsZero() {
+				t.Errorf("FetchedAt = %v, want zero (unknown)", entry.FetchedAt)
+			}
+			if _, ok = c.Get(raw); ok {
+				t.Error("entry of raw uri not removed")
+			}
+		})
+	}
+}
+
+func TestStaleOnRefresh(t *testing.T) {
+	uri := "/data/league/seasons?league_id=100"
+	body := `{"success":true}`
+	tests := []struct {
+		name       string
+		status     int
+		serveStale bool
+		wantSource Source
+		wantErr    bool
+	}{
+		{"maintenance", http.StatusServiceUnavailable, true, SourceStale, false},
+		{"disabled", http.StatusServiceUnavailable, false, SourceNetwork, true},
+		{"not found", http.StatusNotFound, true, SourceNetwork, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := cache.NewMemoryCache(1<<20, nil)
+			if err := c.Set(uri, []byte(body)); err != nil {
+				t.Fatal(err)
+			}
+			i := newTestClient(t, statusServer(tt.status),
+				WithCache(c), WithServeStale(tt.serveStale))
+			i.client.RetryMax = 0
+			resp, err := i.Do(context.Background(), uri, ForceRefresh())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if resp.Source != tt.wantSource {
+				t.Errorf("source = %v, want %v", resp.Source, tt.wantSource)
+			}
+			if !tt.wantErr && string(resp.Body) != body {
+				t.Errorf("body = %s, want %s", resp.Body, body)
+			}
+		})
+	}
+}
+
+func TestMembersStale(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     []int
+		wantErr bool
+	}{
+		{"all cached", idRange(1, 60), false},
+		{"not cached", idRange(1, 61), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := newTestClient(t, statusServer(http.StatusServiceUnavailable),
+				WithCache(cache.NewMemoryCache(1<<20, nil)), WithServeStale(true))
+			i.client.RetryMax = 0
+			for _, id := range idRange(1, 60) {
+				i.cacheMember(id, json.RawMessage(fmt.Sprintf(`{"cust_id":%d}`, id)))
+			}
+			// cached entries are only used as fallback on refresh
+			members, err := i.Members(context.Background(), tt.ids, ForceRefresh())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Members() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(members) != len(tt.ids) {
+				t.Errorf("got %d members, want %d", len(members), len(tt.ids))
+			}
+			if !tt.wantErr && len(i.Stale()) != len(tt.ids) {
+				t.Errorf("got %d stale uris, want %d", len(i.Stale()), len(tt.ids))
+			}
+		})
+	}
+}
+
+// statusServer answers every request with status
+func statusServer(status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	})
+}
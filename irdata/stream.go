@@ -0,0 +1,240 @@
+package irdata
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mpapenbr/irdata/cache"
+	"github.com/mpapenbr/irdata/log"
+)
+
+type (
+	// limitReader fails with ErrPayloadTooLarge once more than max bytes are read
+	limitReader struct {
+		r   io.Reader
+		max int64
+		n   int64
+	}
+
+	// payload combines the (decompressing) reader with the closers
+	// of the underlying readers.
+	payload struct {
+		io.Reader
+		closers []io.Closer
+	}
+
+	// cacheTee collects the data read from rc and passes it to store
+	// once rc has been read completely. If rc exceeds limit (0: no limit),
+	// is not read completely or fails, the data is dropped and abort
+	// is called instead.
+	cacheTee struct {
+		rc    io.ReadCloser
+		buf   bytes.Buffer
+		limit int64
+		store func(data []byte)
+		abort func()
+		done  bool
+	}
+)
+
+// defaultStreamCacheLimit is the size up to which streamed payloads
+// are cached
+const defaultStreamCacheLimit = 64 << 20
+
+// ErrPayloadTooLarge is returned if a payload exceeds the max payload size
+var ErrPayloadTooLarge = errors.New("payload exceeds max size")
+
+// WithMaxPayloadSize limits the size of a (decompressed) payload in bytes.
+// Reading larger payloads fails with ErrPayloadTooLarge. 0 means no limit.
+func WithMaxPayloadSize(arg int64) Option {
+	return func(c *config) {
+		c.maxPayload = arg
+	}
+}
+
+// WithStreamCacheLimit sets the size in bytes up to which payloads read
+// by GetStream are stored in the cache. Larger payloads are streamed
+// without being cached. 0 means no limit. The default is 64 MiB.
+func WithStreamCacheLimit(arg int64) Option {
+	return func(c *config) {
+		c.streamCacheLimit = arg
+	}
+}
+
+// GetStream is like Get but returns a reader on the data. The data is
+// downloaded while being read. If the endpoint is cacheable, the data is
+// stored in the cache once the reader has been read completely (see
+// WithStreamCacheLimit). Concurrent calls for a cacheable uri wait for the
+// first one and are served its data. The reader must be closed after use.
+func (i *IrData) GetStream(
+	ctx context.Context,
+	uri string,
+	opts ...CallOption,
+) (io.ReadCloser, error) {
+	uriRef, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URI: %w", err)
+	}
+	key := cache.NormalizeKey(uri)
+	o := i.resolveCallOptions(opts)
+	useCache := !liveEndpoints[uriRef.Path] && !o.noCache
+	var entry *cache.Entry
+	var cached bool
+	// calls restricted to the cache use it even if a refresh is requested.
+	// Otherwise a refresh looks the entry up as stale candidate only.
+	if useCache && (!o.refresh || o.cacheOnly || i.cfg.serveStale) {
+		entry, cached = i.lookup(uri)
+		if cached && (!o.refresh || o.cacheOnly) && o.usable(entry, time.Now()) {
+			return io.NopCloser(bytes.NewReader(entry.Value)), nil
+		}
+	}
+	rc, err := i.openStream(ctx, key, uriRef, o, useCache)
+	if err != nil {
+		if cached && i.fallbackStale(ctx, key, err, o) {
+			return io.NopCloser(bytes.NewReader(entry.Value)), nil
+		}
+		return nil, err
+	}
+	return rc, nil
+}
+
+// openStream opens uriRef unless o restricts the call to the cache.
+// If store is set, a call for the same key in progress is waited for and
+// its data is returned. Otherwise the data is stored in the cache and
+// shared with concurrent calls once the reader has been read completely.
+func (i *IrData) openStream(
+	ctx context.Context,
+	key string,
+	uriRef *url.URL,
+	o *callOptions,
+	store bool,
+) (io.ReadCloser, error) {
+	if o.cacheOnly {
+		return nil, fmt.Errorf("%w: %s", ErrNotCached, key)
+	}
+	if !store {
+		return i.open(ctx, uriRef, &fetchResult{})
+	}
+	fk := flightKey(key, store)
+	f, owner := i.flights.join(fk)
+	for !owner {
+		res, err := f.wait(ctx)
+		if !errors.Is(err, errNotShared) {
+			if err != nil {
+				return nil, err
+			}
+			return io.NopCloser(bytes.NewReader(res.body)), nil
+		}
+		f, owner = i.flights.join(fk)
+	}
+	res := &fetchResult{fetchedAt: time.Now()}
+	rc, err := i.open(ctx, uriRef, res)
+	if err != nil {
+		i.flights.finish(fk, f, nil, sharedErr(ctx, err))
+		return nil, err
+	}
+	return &cacheTee{
+		rc:    rc,
+		limit: i.cfg.streamCacheLimit,
+		store: func(data []byte) {
+			res.body, res.status = data, http.StatusOK
+			i.stale.remove(key)
+			if cacheErr := i.cfg.cache.Set(key, data); cacheErr != nil {
+				log.Warn("failed to set cache", log.ErrorField(cacheErr))
+			}
+			i.flights.finish(fk, f, res, nil)
+		},
+		abort: func() { i.flights.finish(fk, f, nil, errNotShared) },
+	}, nil
+}
+
+// sharedErr returns the error of a failed call passed to the waiting calls.
+// If the call was canceled, the waiting calls start a request on their own.
+func sharedErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return errNotShared
+	}
+	return err
+}
+
+// payloadReader wraps body with gzip decompression (if body starts with
+// the gzip magic bytes) and the max payload size check.
+func (i *IrData) payloadReader(body io.ReadCloser) (io.ReadCloser, error) {
+	ret := &payload{closers: []io.Closer{body}}
+	br := bufio.NewReader(body)
+	ret.Reader = br
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			body.Close()
+			return nil, fmt.Errorf("failed to read gzip payload: %w", err)
+		}
+		ret.Reader = gz
+		ret.closers = append(ret.closers, gz)
+	}
+	if i.cfg.maxPayload > 0 {
+		ret.Reader = &limitReader{r: ret.Reader, max: i.cfg.maxPayload}
+	}
+	return ret, nil
+}
+
+func (p *payload) Close() error {
+	var errs []error
+	for j := len(p.closers) - 1; j >= 0; j-- {
+		errs = append(errs, p.closers[j].Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.n > l.max {
+		return n, ErrPayloadTooLarge
+	}
+	return n, err
+}
+
+func (c *cacheTee) Read(p []byte) (int, error) {
+	n, err := c.rc.Read(p)
+	if c.done {
+		return n, err
+	}
+	if c.limit > 0 && int64(c.buf.Len()+n) > c.limit {
+		log.Debug("payload exceeds stream cache limit, not caching",
+			log.Int64("limit", c.limit))
+		c.drop()
+		return n, err
+	}
+	c.buf.Write(p[:n])
+	switch {
+	case errors.Is(err, io.EOF):
+		c.done = true
+		c.store(c.buf.Bytes())
+	case err != nil:
+		c.drop()
+	}
+	return n, err
+}
+
+func (c *cacheTee) Close() error {
+	if !c.done {
+		c.drop()
+	}
+	return c.rc.Close()
+}
+
+// drop discards the data collected so far
+func (c *cacheTee) drop() {
+	c.done = true
+	c.buf = bytes.Buffer{}
+	c.abort()
+}
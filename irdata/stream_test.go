@@ -0,0 +1,134 @@
+package irdata
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mpapenbr/irdata/cache"
+)
+
+const streamBody = `{"success":true,"data":"0123456789"}`
+
+// countingServer answers every request with streamBody
+type countingServer struct {
+	requests atomic.Int32
+}
+
+func (s *countingServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.requests.Add(1)
+	//nolint:errcheck // test server
+	io.WriteString(w, streamBody)
+}
+
+func TestGetStreamCache(t *testing.T) {
+	tests := []struct {
+		name         string
+		limit        int64
+		read         int // bytes read before close (-1: all)
+		wantCached   bool
+		wantRequests int32 // after the second call
+	}{
+		{"cached", 0, -1, true, 1},
+		{"within limit", int64(len(streamBody)), -1, true, 1},
+		{"exceeds limit", 8, -1, false, 2},
+		{"closed early", 0, 4, false, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &countingServer{}
+			c := cache.NewMemoryCache(1<<20, nil)
+			i := newTestClient(t, srv, WithCache(c), WithStreamCacheLimit(tt.limit))
+			ctx := context.Background()
+			uri := "/data/car/get"
+
+			rc, err := i.GetStream(ctx, uri)
+			if err != nil {
+				t.Fatalf("GetStream() error = %v", err)
+			}
+			if tt.read < 0 {
+				got, readErr := io.ReadAll(rc)
+				if readErr != nil || string(got) != streamBody {
+					t.Errorf("read %q, %v, want %q", got, readErr, streamBody)
+				}
+			} else if _, err = io.ReadFull(rc, make([]byte, tt.read)); err != nil {
+				t.Fatal(err)
+			}
+			rc.Close()
+			if _, ok := c.Get(uri); ok != tt.wantCached {
+				t.Errorf("cached = %v, want %v", ok, tt.wantCached)
+			}
+
+			rc, err = i.GetStream(ctx, uri)
+			if err != nil {
+				t.Fatalf("GetStream() error = %v", err)
+			}
+			got, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil || string(got) != streamBody {
+				t.Errorf("second read %q, %v, want %q", got, err, streamBody)
+			}
+			if n := srv.requests.Load(); n != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", n, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestGetStreamShared(t *testing.T) {
+	tests := []struct {
+		name         string
+		read         bool // read the first stream completely before closing
+		wantRequests int32
+	}{
+		{"shared", true, 1},
+		{"owner closed early", false, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &countingServer{}
+			i := newTestClient(t, srv, WithCache(cache.NewMemoryCache(1<<20, nil)))
+			ctx := context.Background()
+			uri := "/data/car/get"
+
+			rc, err := i.GetStream(ctx, uri)
+			if err != nil {
+				t.Fatalf("GetStream() error = %v", err)
+			}
+			type result struct {
+				data string
+				err  error
+			}
+			waiter := make(chan result)
+			go func() {
+				wrc, werr := i.GetStream(ctx, uri)
+				if werr != nil {
+					waiter <- result{err: werr}
+					return
+				}
+				defer wrc.Close()
+				data, werr := io.ReadAll(wrc)
+				waiter <- result{string(data), werr}
+			}()
+			// the second call waits for the first one
+			time.Sleep(20 * time.Millisecond)
+			if tt.read {
+				if _, err = io.Copy(io.Discard, rc); err != nil {
+					t.Fatal(err)
+				}
+			}
+			rc.Close()
+
+			res := <-waiter
+			if res.err != nil || res.data != streamBody {
+				t.Errorf("waiter read %q, %v, want %q", res.data, res.err, streamBody)
+			}
+			if n := srv.requests.Load(); n != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", n, tt.wantRequests)
+			}
+		})
+	}
+}
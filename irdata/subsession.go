@@ -0,0 +1,155 @@
+package irdata
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	// SeasonResultsResponse is the response of results/season_results
+	SeasonResultsResponse struct {
+		Type string            `json:"type,omitempty"`
+		Data SeasonResultsData `json:"data"`
+		RawJSON
+	}
+	SeasonResultsData struct {
+		Success     bool           `json:"success,omitempty"`
+		SeasonID    int            `json:"season_id,omitempty"`
+		RaceWeekNum int            `json:"race_week_num,omitempty"`
+		EventType   EventType      `json:"event_type,omitempty"`
+		ResultsList []SeasonResult `json:"results_list,omitempty"`
+	}
+	// SeasonResult is the summary of a session of a season
+	SeasonResult struct {
+		RaceWeekNum          int       `json:"race_week_num,omitempty"`
+		EventType            EventType `json:"event_type,omitempty"`
+		EventTypeName        string    `json:"event_type_name,omitempty"`
+		StartTime            IRTime    `json:"start_time,omitempty"`
+		SessionID            int       `json:"session_id,omitempty"`
+		SubsessionID         int       `json:"subsession_id,omitempty"`
+		OfficialSession      bool      `json:"official_session,omitempty"`
+		EventStrengthOfField int       `json:"event_strength_of_field,omitempty"`
+		EventBestLapTime     LapTime   `json:"event_best_lap_time,omitempty"`
+		NumCautions          int       `json:"num_cautions,omitempty"`
+		NumCautionLaps       int       `json:"num_caution_laps,omitempty"`
+		NumLeadChanges       int       `json:"num_lead_changes,omitempty"`
+		DriverChanges        bool      `json:"driver_changes,omitempty"`
+		WinnerGroupID        int       `json:"winner_group_id,omitempty"`
+		WinnerName           string    `json:"winner_name,omitempty"`
+		WinnerAI             bool      `json:"winner_ai,omitempty"`
+		Track                TrackRef  `json:"track"`
+		NumDrivers           int       `json:"num_drivers,omitempty"`
+	}
+
+	// SubsessionResult is the response of results/get
+	SubsessionResult struct {
+		SubsessionID         int                `json:"subsession_id,omitempty"`
+		SessionID            int                `json:"session_id,omitempty"`
+		SeasonID             int                `json:"season_id,omitempty"`
+		SeasonName           string             `json:"season_name,omitempty"`
+		SeasonYear           int                `json:"season_year,omitempty"`
+		SeasonQuarter        int                `json:"season_quarter,omitempty"`
+		SeriesID             int                `json:"series_id,omitempty"`
+		SeriesName           string             `json:"series_name,omitempty"`
+		RaceWeekNum          int                `json:"race_week_num,omitempty"`
+		LicenseCategoryID    Category           `json:"license_category_id,omitempty"`
+		LicenseCategory      string             `json:"license_category,omitempty"`
+		StartTime            IRTime             `json:"start_time,omitempty"`
+		EndTime              IRTime             `json:"end_time,omitempty"`
+		EventType            EventType          `json:"event_type,omitempty"`
+		EventTypeName        string             `json:"event_type_name,omitempty"`
+		DriverChanges        bool               `json:"driver_changes,omitempty"`
+		OfficialSession      bool               `json:"official_session,omitempty"`
+		Track                TrackRef           `json:"track"`
+		EventStrengthOfField int                `json:"event_strength_of_field,omitempty"`
+		EventAverageLap      LapTime            `json:"event_average_lap,omitempty"`
+		EventLapsComplete    int                `json:"event_laps_complete,omitempty"`
+		NumCautions          int                `json:"num_cautions,omitempty"`
+		NumCautionLaps       int                `json:"num_caution_laps,omitempty"`
+		NumLeadChanges       int                `json:"num_lead_changes,omitempty"`
+		SessionResults       []SimSessionResult `json:"session_results,omitempty"`
+		RawJSON
+	}
+	// SimSessionResult contains the results of a sim session
+	// (practice, qualifying, race) of a subsession
+	SimSessionResult struct {
+		SimsessionNumber   int            `json:"simsession_number"`
+		SimsessionType     int            `json:"simsession_type,omitempty"`
+		SimsessionTypeName string         `json:"simsession_type_name,omitempty"`
+		SimsessionName     string         `json:"simsession_name,omitempty"`
+		Results            []DriverResult `json:"results,omitempty"`
+	}
+	// DriverResult is the result of a driver or team in a sim session.
+	// For team events DriverResults contains the results of the team drivers.
+	DriverResult struct {
+		CustID                  int            `json:"cust_id,omitempty"`
+		TeamID                  int            `json:"team_id,omitempty"`
+		DisplayName             string         `json:"display_name,omitempty"`
+		FinishPosition          int            `json:"finish_position"`
+		FinishPositionInClass   int            `json:"finish_position_in_class"`
+		StartingPosition        int            `json:"starting_position"`
+		StartingPositionInClass int            `json:"starting_position_in_class"`
+		LapsLead                int            `json:"laps_lead,omitempty"`
+		LapsComplete            int            `json:"laps_complete,omitempty"`
+		Interval                LapTime        `json:"interval,omitempty"`
+		ClassInterval           LapTime        `json:"class_interval,omitempty"`
+		AverageLap              LapTime        `json:"average_lap,omitempty"`
+		BestLapNum              int            `json:"best_lap_num,omitempty"`
+		BestLapTime             LapTime        `json:"best_lap_time,omitempty"`
+		QualLapTime             LapTime        `json:"qual_lap_time,omitempty"`
+		Incidents               int            `json:"incidents,omitempty"`
+		ReasonOutID             int            `json:"reason_out_id,omitempty"`
+		ReasonOut               string         `json:"reason_out,omitempty"`
+		ChampPoints             int            `json:"champ_points,omitempty"`
+		CarID                   int            `json:"car_id,omitempty"`
+		CarName                 string         `json:"car_name,omitempty"`
+		CarClassID              int            `json:"car_class_id,omitempty"`
+		CarClassName            string         `json:"car_class_name,omitempty"`
+		Division                int            `json:"division,omitempty"`
+		OldiRating              int            `json:"oldi_rating,omitempty"`
+		NewiRating              int            `json:"newi_rating,omitempty"`
+		OldLicenseLevel         int            `json:"old_license_level,omitempty"`
+		NewLicenseLevel         int            `json:"new_license_level,omitempty"`
+		OldSubLevel             int            `json:"old_sub_level,omitempty"`
+		NewSubLevel             int            `json:"new_sub_level,omitempty"`
+		AI                      bool           `json:"ai,omitempty"`
+		DriverResults           []DriverResult `json:"driver_results,omitempty"`
+	}
+)
+
+// SeasonResults returns the sessions of a race week (0-based) of a season.
+// eventType is an optional filter (0 means no filter).
+func (i *IrData) SeasonResults(
+	ctx context.Context,
+	seasonID, raceWeekNum int,
+	eventType EventType,
+	opts ...CallOption,
+) (*SeasonResultsResponse, error) {
+	v := url.Values{}
+	addInt(v, "season_id", seasonID)
+	// week 0 is a valid race week, not a default
+	v.Set("race_week_num", strconv.Itoa(raceWeekNum))
+	addInt(v, "event_type", int(eventType))
+	return GetAs[*SeasonResultsResponse](
+		ctx,
+		i,
+		"/data/results/season_results",
+		v,
+		opts...,
+	)
+}
+
+// Subsession returns the results of a subsession.
+func (i *IrData) Subsession(
+	ctx context.Context,
+	subsessionID int,
+	includeLicenses bool,
+	opts ...CallOption,
+) (*SubsessionResult, error) {
+	v := url.Values{}
+	addInt(v, "subsession_id", subsessionID)
+	addBool(v, "include_licenses", includeLicenses)
+	return GetAs[*SubsessionResult](ctx, i, "/data/results/get", v, opts...)
+}
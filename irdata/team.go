@@ -0,0 +1,227 @@
+package irdata
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/mpapenbr/irdata/log"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	// Team is the response of team/get. It contains the owner and the roster,
+	// the league memberships of the team are collected by TeamLeagues.
+	Team struct {
+		TeamID      int          `json:"team_id,omitempty"`
+		OwnerID     int          `json:"owner_id,omitempty"`
+		TeamName    string       `json:"team_name,omitempty"`
+		Created     IRTime       `json:"created,omitempty"`
+		About       string       `json:"about,omitempty"`
+		URL         string       `json:"url,omitempty"`
+		Hidden      bool         `json:"hidden,omitempty"`
+		Recruiting  bool         `json:"recruiting,omitempty"`
+		IsOwner     bool         `json:"is_owner,omitempty"`
+		IsAdmin     bool         `json:"is_admin,omitempty"`
+		IsMember    bool         `json:"is_member,omitempty"`
+		RosterCount int          `json:"roster_count,omitempty"`
+		Owner       TeamMember   `json:"owner"`
+		Roster      []TeamMember `json:"roster,omitempty"`
+		RawJSON
+	}
+	TeamMember struct {
+		CustID      int    `json:"cust_id,omitempty"`
+		DisplayName string `json:"display_name,omitempty"`
+		Owner       bool   `json:"owner,omitempty"`
+		Admin       bool   `json:"admin,omitempty"`
+	}
+)
+
+type (
+	// TeamResults contains the results of a team in a time range
+	TeamResults struct {
+		Team    *Team
+		Leagues []TeamLeague
+		Since   time.Time
+		Until   time.Time
+		Results []TeamResult
+	}
+	// TeamLeague is a league roster members of a team are members of.
+	// Members contains these roster members.
+	TeamLeague struct {
+		LeagueID   int
+		LeagueName string
+		Members    []MemberRef
+	}
+	// TeamResult is a session the team participated in. Drivers contains the
+	// roster members that drove for the team in this session (as far as known).
+	TeamResult struct {
+		SearchResult
+		Drivers []MemberRef
+	}
+)
+
+func (i *IrData) Team(
+	ctx context.Context,
+	teamID int,
+	includeLicenses bool,
+	opts ...CallOption,
+) (*Team, error) {
+	v := url.Values{}
+	addInt(v, "team_id", teamID)
+	addBool(v, "include_licenses", includeLicenses)
+	return GetAs[*Team](ctx, i, "/data/team/get", v, opts...)
+}
+
+// TeamResults collects the series and hosted results of the team that
+// finished between since and until. Besides searching by team the results of
+// each roster member are searched as well to find the drivers of each session.
+// The results are sorted by start time.
+func (i *IrData) TeamResults(
+	ctx context.Context,
+	teamID int,
+	since, until time.Time,
+	opts ...CallOption,
+) (*TeamResults, error) {
+	team, err := i.Team(ctx, teamID, false, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c := &teamResultCollector{teamID: teamID, results: map[int]*TeamResult{}}
+	for begin := since; begin.Before(until); begin = begin.Add(MaxSearchRange) {
+		end := begin.Add(MaxSearchRange)
+		if end.After(until) {
+			end = until
+		}
+		p := ResultsSearchParams{
+			FinishRangeBegin: begin,
+			FinishRangeEnd:   end,
+			TeamID:           teamID,
+		}
+		rows, err := i.searchAll(ctx, &p, opts...)
+		if err != nil {
+			return nil, err
+		}
+		c.add(rows, nil)
+
+		p.TeamID = 0
+		for j := range team.Roster {
+			m := &team.Roster[j]
+			p.CustID = m.CustID
+			rows, err := i.searchAll(ctx, &p, opts...)
+			if err != nil {
+				log.Warn("failed to search results of team member",
+					log.Int("cust_id", m.CustID),
+					log.ErrorField(err))
+				continue
+			}
+			c.add(rows, &MemberRef{CustID: m.CustID, DisplayName: m.DisplayName})
+		}
+	}
+	return &TeamResults{
+		Team:    team,
+		Leagues: i.TeamLeagues(ctx, team, opts...),
+		Since:   since,
+		Until:   until,
+		Results: c.sorted(),
+	}, nil
+}
+
+// TeamLeagues collects the league memberships of the roster members of team.
+// The data API provides no memberships of teams, so the memberships of each
+// member are looked up (see LeagueMemberships). Members whose memberships are
+// not public or can't be fetched are skipped. The leagues are sorted by name.
+func (i *IrData) TeamLeagues(
+	ctx context.Context,
+	team *Team,
+	opts ...CallOption,
+) []TeamLeague {
+	leagues := map[int]*TeamLeague{}
+	for j := range team.Roster {
+		m := &team.Roster[j]
+		memberships, err := i.LeagueMemberships(ctx, m.CustID, opts...)
+		if err != nil {
+			log.Warn("failed to get league memberships of team member",
+				log.Int("cust_id", m.CustID),
+				log.ErrorField(err))
+			continue
+		}
+		for k := range memberships {
+			ms := &memberships[k]
+			l, ok := leagues[ms.LeagueID]
+			if !ok {
+				l = &TeamLeague{LeagueID: ms.LeagueID, LeagueName: ms.LeagueName}
+				leagues[ms.LeagueID] = l
+			}
+			l.Members = append(l.Members, MemberRef{
+				CustID:      m.CustID,
+				DisplayName: m.DisplayName,
+				CarNumber:   ms.CarNumber,
+				NickName:    ms.NickName,
+			})
+		}
+	}
+	ret := make([]TeamLeague, 0, len(leagues))
+	for _, l := range leagues {
+		ret = append(ret, *l)
+	}
+	sort.Slice(ret, func(a, b int) bool {
+		return ret[a].LeagueName < ret[b].LeagueName
+	})
+	return ret
+}
+
+// searchAll searches series and hosted results
+func (i *IrData) searchAll(
+	ctx context.Context,
+	p *ResultsSearchParams,
+	opts ...CallOption,
+) ([]SearchResult, error) {
+	series, err := i.SearchSeriesResults(ctx, p, opts...)
+	if err != nil {
+		return nil, err
+	}
+	hosted, err := i.SearchHostedResults(ctx, p, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return append(series, hosted...), nil
+}
+
+type teamResultCollector struct {
+	teamID  int
+	results map[int]*TeamResult
+}
+
+// add adds rows belonging to the team. If driver is not nil, the rows are
+// the results of this driver.
+func (c *teamResultCollector) add(rows []SearchResult, driver *MemberRef) {
+	for j := range rows {
+		row := &rows[j]
+		if row.TeamID != c.teamID {
+			continue
+		}
+		res, ok := c.results[row.SubsessionID]
+		if !ok {
+			res = &TeamResult{SearchResult: *row}
+			c.results[row.SubsessionID] = res
+		}
+		if driver != nil && !slices.ContainsFunc(res.Drivers,
+			func(m MemberRef) bool { return m.CustID == driver.CustID }) {
+			res.Drivers = append(res.Drivers, *driver)
+		}
+	}
+}
+
+func (c *teamResultCollector) sorted() []TeamResult {
+	ret := make([]TeamResult, 0, len(c.results))
+	for _, r := range c.results {
+		ret = append(ret, *r)
+	}
+	sort.Slice(ret, func(a, b int) bool {
+		return ret[a].StartTime.Before(ret[b].StartTime.Time)
+	})
+	return ret
+}
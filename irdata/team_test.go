@@ -0,0 +1,46 @@
+package irdata
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTeamLeagues(t *testing.T) {
+	// league memberships by cust_id, the request for 3 fails
+	memberships := map[string][]LeagueMembership{
+		"1": {
+			{LeagueID: 20, LeagueName: "Endurance League", CarNumber: "7"},
+			{LeagueID: 10, LeagueName: "Club League"},
+		},
+		"2": {{LeagueID: 20, LeagueName: "Endurance League", CarNumber: "8"}},
+	}
+	srv := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ms, ok := memberships[r.URL.Query().Get("cust_id")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		//nolint:errcheck // test server
+		json.NewEncoder(w).Encode(ms)
+	})
+	i := newTestClient(t, srv)
+	team := &Team{Roster: []TeamMember{
+		{CustID: 1, DisplayName: "Driver 1"},
+		{CustID: 2, DisplayName: "Driver 2"},
+		{CustID: 3, DisplayName: "Driver 3"},
+	}}
+	want := []TeamLeague{
+		{LeagueID: 10, LeagueName: "Club League", Members: []MemberRef{
+			{CustID: 1, DisplayName: "Driver 1"},
+		}},
+		{LeagueID: 20, LeagueName: "Endurance League", Members: []MemberRef{
+			{CustID: 1, DisplayName: "Driver 1", CarNumber: "7"},
+			{CustID: 2, DisplayName: "Driver 2", CarNumber: "8"},
+		}},
+	}
+	if got := i.TeamLeagues(t.Context(), team); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,169 @@
+package irdata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type (
+	// LapTime is a lap time in 1/10000 seconds as delivered by the data API.
+	// Values <= 0 are used by the API for "no lap time" (see Valid).
+	// In JSON it is encoded as the plain number.
+	LapTime int
+
+	// Minutes is a duration in minutes as delivered by the data API, for
+	// example the length of a session. In JSON it is encoded as the plain
+	// number.
+	Minutes int
+
+	// IRTime is a timestamp of the data API. It accepts RFC3339 timestamps
+	// as well as the minute precision format "2006-01-02T15:04Z" and
+	// timestamps without zone (interpreted as UTC).
+	// null and empty strings are decoded as zero time and vice versa.
+	IRTime struct {
+		time.Time
+	}
+
+	// IRDate is a date ("2006-01-02") of the data API.
+	IRDate struct {
+		time.Time
+	}
+)
+
+const lapTimeUnit = 100 * time.Microsecond
+
+var (
+	irTimeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02T15:04:05",
+	}
+	irDateLayouts = []string{time.DateOnly, time.RFC3339Nano}
+	jsonNull      = []byte("null")
+)
+
+// LapTimeOf converts d to a LapTime (truncated to 1/10000 seconds)
+func LapTimeOf(d time.Duration) LapTime {
+	return LapTime(d / lapTimeUnit)
+}
+
+// Valid reports whether t is an actual lap time
+func (t LapTime) Valid() bool {
+	return t > 0
+}
+
+func (t LapTime) Duration() time.Duration {
+	return time.Duration(t) * lapTimeUnit
+}
+
+func (t LapTime) Seconds() float64 {
+	return float64(t) / 10000
+}
+
+func (t LapTime) Add(o LapTime) LapTime {
+	return t + o
+}
+
+// Sub returns the gap between t and o (negative if t is faster)
+func (t LapTime) Sub(o LapTime) LapTime {
+	return t - o
+}
+
+// String formats t as m:ss.sss, for example 1:23.456. Values truncated
+// to zero milliseconds are formatted without sign.
+func (t LapTime) String() string {
+	sign := ""
+	if t <= -10 {
+		sign = "-"
+	}
+	ms := int(t) / 10
+	if ms < 0 {
+		ms = -ms
+	}
+	return fmt.Sprintf("%s%d:%02d.%03d", sign, ms/60000, (ms/1000)%60, ms%1000)
+}
+
+func (m Minutes) Duration() time.Duration {
+	return time.Duration(m) * time.Minute
+}
+
+// NewIRTime returns t as IRTime
+func NewIRTime(t time.Time) IRTime {
+	return IRTime{Time: t}
+}
+
+func (t IRTime) Add(d time.Duration) IRTime {
+	return IRTime{Time: t.Time.Add(d)}
+}
+
+func (t IRTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return jsonNull, nil
+	}
+	return json.Marshal(t.UTC().Format(time.RFC3339))
+}
+
+func (t *IRTime) UnmarshalJSON(data []byte) error {
+	parsed, err := parseJSONTime(data, irTimeLayouts)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// NewIRDate returns the date of t (in the location of t)
+func NewIRDate(t time.Time) IRDate {
+	y, m, d := t.Date()
+	return IRDate{Time: time.Date(y, m, d, 0, 0, 0, 0, time.UTC)}
+}
+
+func (d IRDate) AddDays(n int) IRDate {
+	return IRDate{Time: d.AddDate(0, 0, n)}
+}
+
+func (d IRDate) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(time.DateOnly)
+}
+
+func (d IRDate) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return jsonNull, nil
+	}
+	return json.Marshal(d.String())
+}
+
+func (d *IRDate) UnmarshalJSON(data []byte) error {
+	parsed, err := parseJSONTime(data, irDateLayouts)
+	if err != nil {
+		return err
+	}
+	*d = NewIRDate(parsed)
+	return nil
+}
+
+// parseJSONTime parses the JSON string data with the first matching layout.
+// Timestamps without zone are interpreted as UTC.
+func parseJSONTime(data []byte, layouts []string) (time.Time, error) {
+	if bytes.Equal(data, jsonNull) {
+		return time.Time{}, nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return time.Time{}, err
+	}
+	if s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported time format: %q", s)
+}
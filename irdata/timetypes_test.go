@@ -0,0 +1,185 @@
+package irdata
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLapTimeUnmarshal(t *testing.T) {
+	tests := []struct {
+		in        string
+		want      LapTime
+		wantValid bool
+		wantErr   bool
+	}{
+		{"834560", 834560, true, false},
+		{"0", 0, false, false},
+		{"-1", -1, false, false},
+		{"null", 0, false, false},
+		{`"834560"`, 0, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var got LapTime
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+			if got.Valid() != tt.wantValid {
+				t.Errorf("Valid() = %v, want %v", got.Valid(), tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestSessionSettingsMinutes(t *testing.T) {
+	data := `{"practice_length":60,"qualify_length":0,"race_length":150,` +
+		`"time_limit":240}`
+	var got HostedSessionSettings
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		in   Minutes
+		want time.Duration
+	}{
+		{"practice", got.PracticeLength, time.Hour},
+		{"qualify", got.QualifyLength, 0},
+		{"race", got.RaceLength, 150 * time.Minute},
+		{"time limit", got.TimeLimit, 4 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := tt.in.Duration(); d != tt.want {
+				t.Errorf("Duration() = %v, want %v", d, tt.want)
+			}
+		})
+	}
+}
+
+func TestLapTimeString(t *testing.T) {
+	tests := []struct {
+		in   LapTime
+		want string
+	}{
+		{834560, "1:23.456"},
+		{LapTimeOf(59*time.Second + 999*time.Millisecond), "0:59.999"},
+		{LapTimeOf(61 * time.Minute), "61:00.000"},
+		{0, "0:00.000"},
+		{-1, "0:00.000"},
+		{-9, "0:00.000"},
+		{-10, "-0:00.001"},
+		{-15230, "-0:01.523"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.in.String(); got != tt.want {
+				t.Errorf("LapTime(%d).String() = %q, want %q", int(tt.in), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIRTimeUnmarshal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			`"2026-03-17T18:45:12Z"`,
+			time.Date(2026, 3, 17, 18, 45, 12, 0, time.UTC), false,
+		},
+		{
+			`"2026-03-17T18:45:12.345+01:00"`,
+			time.Date(2026, 3, 17, 17, 45, 12, 345e6, time.UTC), false,
+		},
+		{`"2026-03-17T18:45Z"`, time.Date(2026, 3, 17, 18, 45, 0, 0, time.UTC), false},
+		{
+			`"2026-03-17T18:45:12"`,
+			time.Date(2026, 3, 17, 18, 45, 12, 0, time.UTC), false,
+		},
+		{`null`, time.Time{}, false},
+		{`""`, time.Time{}, false},
+		{`"17.03.2026"`, time.Time{}, true},
+		{`1773773112`, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var got IRTime
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestIRTimeMarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		in   IRTime
+		want string
+	}{
+		{"zero", IRTime{}, `null`},
+		{
+			"utc",
+			NewIRTime(time.Date(2026, 3, 17, 18, 45, 12, 0, time.UTC)),
+			`"2026-03-17T18:45:12Z"`,
+		},
+		{
+			"zone",
+			NewIRTime(time.Date(2026, 3, 17, 19, 45, 12, 0, time.FixedZone("", 3600))),
+			`"2026-03-17T18:45:12Z"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.in)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIRDateUnmarshal(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{`"2026-03-17"`, "2026-03-17", false},
+		{`"2026-03-17T00:00:00Z"`, "2026-03-17", false},
+		{`"2026-03-17T23:30:00-02:00"`, "2026-03-17", false},
+		{`null`, "", false},
+		{`""`, "", false},
+		{`"03/17/2026"`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var got IRDate
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.String() != tt.want {
+				t.Errorf("got %q, want %q", got.String(), tt.want)
+			}
+			if !got.IsZero() && got.Location() != time.UTC {
+				t.Errorf("location = %v, want UTC", got.Location())
+			}
+		})
+	}
+}
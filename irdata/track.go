@@ -0,0 +1,93 @@
+package irdata
+
+import (
+	"context"
+	"strings"
+)
+
+//nolint:tagliatelle // external definition
+type (
+	Track struct {
+		TrackID           int     `json:"track_id,omitempty"`
+		TrackName         string  `json:"track_name,omitempty"`
+		ConfigName        string  `json:"config_name,omitempty"`
+		Category          string  `json:"category,omitempty"`
+		CategoryID        int     `json:"category_id,omitempty"`
+		Location          string  `json:"location,omitempty"`
+		TrackConfigLength float64 `json:"track_config_length,omitempty"`
+		CornersPerLap     int     `json:"corners_per_lap,omitempty"`
+		FreeWithSubscr    bool    `json:"free_with_subscription,omitempty"`
+		PackageID         int     `json:"package_id,omitempty"`
+		Retired           bool    `json:"retired,omitempty"`
+		TimeZone          string  `json:"time_zone,omitempty"`
+		Latitude          float64 `json:"latitude,omitempty"`
+		Longitude         float64 `json:"longitude,omitempty"`
+	}
+	TrackAsset struct {
+		TrackID        int               `json:"track_id,omitempty"`
+		Folder         string            `json:"folder,omitempty"`
+		LargeImage     string            `json:"large_image,omitempty"`
+		SmallImage     string            `json:"small_image,omitempty"`
+		Logo           string            `json:"logo,omitempty"`
+		TrackMap       string            `json:"track_map,omitempty"`
+		TrackMapLayers map[string]string `json:"track_map_layers,omitempty"`
+	}
+)
+
+// FullName returns the track name including the config name (if any).
+func (t *Track) FullName() string {
+	return trackFullName(t.TrackName, t.ConfigName)
+}
+
+// FullName returns the track name including the config name (if any).
+func (t *TrackRef) FullName() string {
+	return trackFullName(t.TrackName, t.ConfigName)
+}
+
+func trackFullName(trackName, configName string) string {
+	if configName == "" {
+		return trackName
+	}
+	return trackName + " - " + configName
+}
+
+// LargeImageURL returns the absolute URL of the large track image.
+func (a *TrackAsset) LargeImageURL() string {
+	return assetURL(a.Folder, a.LargeImage)
+}
+
+// SmallImageURL returns the absolute URL of the small track image.
+func (a *TrackAsset) SmallImageURL() string {
+	return assetURL(a.Folder, a.SmallImage)
+}
+
+// LogoURL returns the absolute URL of the track logo.
+func (a *TrackAsset) LogoURL() string {
+	return assetURL(a.Logo)
+}
+
+// TrackMapLayerURLs returns the absolute URLs of the SVG track map layers
+// indexed by layer name (background, inactive, active, pitroad, ...).
+func (a *TrackAsset) TrackMapLayerURLs() map[string]string {
+	ret := make(map[string]string, len(a.TrackMapLayers))
+	for layer, file := range a.TrackMapLayers {
+		if strings.HasPrefix(a.TrackMap, "http") {
+			ret[layer] = strings.TrimSuffix(a.TrackMap, "/") + "/" + file
+		} else {
+			ret[layer] = assetURL(a.TrackMap, file)
+		}
+	}
+	return ret
+}
+
+func (i *IrData) Tracks(ctx context.Context, opts ...CallOption) ([]Track, error) {
+	return GetAs[[]Track](ctx, i, "/data/track/get", nil, opts...)
+}
+
+// TrackAssets returns the track assets indexed by track id.
+func (i *IrData) TrackAssets(
+	ctx context.Context,
+	opts ...CallOption,
+) (map[int]TrackAsset, error) {
+	return GetAs[map[int]TrackAsset](ctx, i, "/data/track/assets", nil, opts...)
+}